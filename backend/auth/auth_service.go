@@ -1,23 +1,29 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/Wildcard209/portfolio-webapplication/models"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
-	jwtSecret          []byte
+	keys               *KeySet
+	revocations        *RevocationList
 	tokenExpiry        time.Duration
 	refreshTokenExpiry time.Duration
 }
 
-func NewAuthService(jwtSecret string, tokenExpiry time.Duration) *AuthService {
+func NewAuthService(keys *KeySet, revocations *RevocationList, tokenExpiry time.Duration) *AuthService {
 	return &AuthService{
-		jwtSecret:          []byte(jwtSecret),
+		keys:               keys,
+		revocations:        revocations,
 		tokenExpiry:        tokenExpiry,
 		refreshTokenExpiry: 7 * 24 * time.Hour, // 7 days
 	}
@@ -26,12 +32,18 @@ func NewAuthService(jwtSecret string, tokenExpiry time.Duration) *AuthService {
 type CustomClaims struct {
 	UserID    int    `json:"user_id"`
 	Username  string `json:"username"`
-	TokenType string `json:"token_type"` // "access" or "refresh"
+	TokenType string `json:"token_type"` // "access", "refresh" or "mfa_pending"
+	SessionID string `json:"sid,omitempty"`
+	// Role and Permissions are fixed when the token is issued. Access tokens carry both,
+	// refresh tokens only the role.
+	Role        models.AdminRole `json:"role,omitempty"`
+	Permissions []string         `json:"perms,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type TokenPair struct {
 	AccessToken      string
+	AccessTokenID    string
 	RefreshToken     string
 	AccessExpiresAt  time.Time
 	RefreshExpiresAt time.Time
@@ -47,6 +59,63 @@ func (s *AuthService) HashPassword(password string) (string, error) {
 	return string(hashedBytes), nil
 }
 
+// GenerateTemporaryPassword returns a random password that satisfies the
+// InputSanitizer.ValidatePassword policy, for accounts created without one
+func (s *AuthService) GenerateTemporaryPassword() (string, error) {
+	const length = 20
+	charSets := []string{
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"abcdefghijkmnopqrstuvwxyz",
+		"23456789",
+		"!@#$%^&*-_=+?",
+	}
+
+	password := make([]byte, 0, length)
+	// One character from each set guarantees the policy, the rest are drawn from all sets
+	for _, set := range charSets {
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	allChars := charSets[0] + charSets[1] + charSets[2] + charSets[3]
+	for len(password) < length {
+		c, err := randomChar(allChars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate password: %w", err)
+	}
+	return set[n.Int64()], nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // VerifyPassword handles both new bcrypt-only hashes and legacy salt-based hashes
 func (s *AuthService) VerifyPassword(hashedPassword, password string, salt ...string) error {
 	// If no salt is provided or salt is empty, use new bcrypt-only method
@@ -79,50 +148,69 @@ func (s *AuthService) VerifyPasswordLegacy(hashedPassword, password, salt string
 	return errors.New("legacy password format no longer supported - please reset your password")
 }
 
-func (s *AuthService) GenerateTokenPair(userID int, username string) (*TokenPair, error) {
+// GenerateTokenPair issues an access and refresh token bound to a session. The access token
+// carries the permissions of the given role.
+func (s *AuthService) GenerateTokenPair(userID int, username string, role models.AdminRole, sessionID string) (*TokenPair, error) {
 	accessExpirationTime := time.Now().Add(s.tokenExpiry)
 	refreshExpirationTime := time.Now().Add(s.refreshTokenExpiry)
 
-	// Generate access token
+	// Generate access token. Its ID is what the revocation list refers to.
+	accessTokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
 	accessClaims := &CustomClaims{
-		UserID:    userID,
-		Username:  username,
-		TokenType: "access",
+		UserID:      userID,
+		Username:    username,
+		TokenType:   "access",
+		SessionID:   sessionID,
+		Role:        role,
+		Permissions: PermissionsForRole(role),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(accessExpirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Subject:   fmt.Sprintf("%d", userID),
+			ID:        accessTokenID,
 		},
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString(s.jwtSecret)
+	accessTokenString, err := s.keys.sign(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
 
-	// Generate refresh token
+	// Generate refresh token. The random ID keeps every refresh token in a rotation
+	// family distinct, even when two are issued within the same second.
+	refreshTokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
 	refreshClaims := &CustomClaims{
 		UserID:    userID,
 		Username:  username,
 		TokenType: "refresh",
+		SessionID: sessionID,
+		Role:      role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(refreshExpirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Subject:   fmt.Sprintf("%d", userID),
+			ID:        refreshTokenID,
 		},
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString(s.jwtSecret)
+	refreshTokenString, err := s.keys.sign(refreshClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
 	return &TokenPair{
 		AccessToken:      accessTokenString,
+		AccessTokenID:    accessTokenID,
 		RefreshToken:     refreshTokenString,
 		AccessExpiresAt:  accessExpirationTime,
 		RefreshExpiresAt: refreshExpirationTime,
@@ -143,8 +231,7 @@ func (s *AuthService) GenerateToken(userID int, username string) (string, time.T
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtSecret)
+	tokenString, err := s.keys.sign(claims)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -153,12 +240,7 @@ func (s *AuthService) GenerateToken(userID int, username string) (string, time.T
 }
 
 func (s *AuthService) ValidateToken(tokenString string) (*CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, s.keys.verificationKey)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -184,9 +266,28 @@ func (s *AuthService) ValidateAccessToken(tokenString string) (*CustomClaims, er
 		return nil, errors.New("invalid token type: expected access token")
 	}
 
+	if claims.ID != "" && s.revocations.IsRevoked(claims.ID) {
+		return nil, errors.New("token has been revoked")
+	}
+
 	return claims, nil
 }
 
+// RevokeAccessToken rejects an access token from now until it expires
+func (s *AuthService) RevokeAccessToken(token models.RevokedToken) error {
+	return s.revocations.Revoke(token)
+}
+
+// LoadRevokedTokens reads persisted revocations into memory
+func (s *AuthService) LoadRevokedTokens() error {
+	return s.revocations.Load()
+}
+
+// PruneRevokedTokens forgets revocations of tokens that have since expired
+func (s *AuthService) PruneRevokedTokens() error {
+	return s.revocations.Prune()
+}
+
 func (s *AuthService) ValidateRefreshToken(tokenString string) (*CustomClaims, error) {
 	claims, err := s.ValidateToken(tokenString)
 	if err != nil {
@@ -206,7 +307,53 @@ func (s *AuthService) RefreshTokenPair(refreshTokenString string) (*TokenPair, e
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
 
-	return s.GenerateTokenPair(claims.UserID, claims.Username)
+	return s.GenerateTokenPair(claims.UserID, claims.Username, claims.Role, claims.SessionID)
+}
+
+// mfaTokenExpiry bounds how long the second login step may take after the password is accepted
+const mfaTokenExpiry = 5 * time.Minute
+
+// GenerateMFAToken issues a short-lived token proving the password step of login succeeded.
+// It cannot be used as an access or refresh token.
+func (s *AuthService) GenerateMFAToken(userID int, username string) (string, time.Time, error) {
+	expirationTime := time.Now().Add(mfaTokenExpiry)
+
+	claims := &CustomClaims{
+		UserID:    userID,
+		Username:  username,
+		TokenType: "mfa_pending",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Subject:   fmt.Sprintf("%d", userID),
+		},
+	}
+
+	tokenString, err := s.keys.sign(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign MFA token: %w", err)
+	}
+
+	return tokenString, expirationTime, nil
+}
+
+func (s *AuthService) ValidateMFAToken(tokenString string) (*CustomClaims, error) {
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != "mfa_pending" {
+		return nil, errors.New("invalid token type: expected MFA token")
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys other services can use to verify tokens locally
+func (s *AuthService) JWKS() JWKSet {
+	return s.keys.JWKS()
 }
 
 func (s *AuthService) ExtractTokenFromHeader(authHeader string) (string, error) {
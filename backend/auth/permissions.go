@@ -0,0 +1,83 @@
+package auth
+
+import "github.com/Wildcard209/portfolio-webapplication/models"
+
+// Permissions carried in access tokens and checked by middleware.RequirePermission
+const (
+	PermissionContentRead    = "content:read"
+	PermissionContentWrite   = "content:write"
+	PermissionAssetsRead     = "assets:read"
+	PermissionAssetsWrite    = "assets:write"
+	PermissionMessagesRead   = "messages:read"
+	PermissionMessagesWrite  = "messages:write"
+	PermissionUsersManage    = "users:manage"
+	PermissionSecurityManage = "security:manage"
+)
+
+var readPermissions = []string{
+	PermissionContentRead,
+	PermissionAssetsRead,
+	PermissionMessagesRead,
+}
+
+var editorPermissions = append(append([]string{}, readPermissions...),
+	PermissionContentWrite,
+	PermissionAssetsWrite,
+	PermissionMessagesWrite,
+)
+
+var ownerPermissions = append(append([]string{}, editorPermissions...),
+	PermissionUsersManage,
+	PermissionSecurityManage,
+)
+
+// APIKeyScopes are the permissions an API key may be granted. Managing admins and security
+// settings always needs an interactive session.
+var APIKeyScopes = append([]string{}, editorPermissions...)
+
+// PermissionsForRole returns the permissions granted to a role. Editors manage content but
+// not other admins or security settings; viewers can only read. Unknown roles get nothing.
+func PermissionsForRole(role models.AdminRole) []string {
+	var permissions []string
+	switch role {
+	case models.AdminRoleOwner:
+		permissions = ownerPermissions
+	case models.AdminRoleEditor:
+		permissions = editorPermissions
+	case models.AdminRoleViewer:
+		permissions = readPermissions
+	}
+	return append([]string{}, permissions...)
+}
+
+// EffectivePermissions narrows the permissions in a token to those the admin's current role
+// still grants, so a demotion takes effect before the token expires. Tokens issued before roles
+// existed carry neither, and get the role's permissions.
+func EffectivePermissions(claims *CustomClaims, role models.AdminRole) []string {
+	if claims.Role == "" && claims.Permissions == nil {
+		return PermissionsForRole(role)
+	}
+
+	granted := make(map[string]bool)
+	for _, permission := range PermissionsForRole(role) {
+		granted[permission] = true
+	}
+
+	effective := []string{}
+	for _, permission := range claims.Permissions {
+		if granted[permission] {
+			effective = append(effective, permission)
+		}
+	}
+	return effective
+}
+
+// HasPermission reports whether permission is in the list
+func HasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims CustomClaims
+		role   models.AdminRole
+		want   []string
+	}{
+		{
+			name:   "token matches the current role",
+			claims: CustomClaims{Role: models.AdminRoleEditor, Permissions: PermissionsForRole(models.AdminRoleEditor)},
+			role:   models.AdminRoleEditor,
+			want:   editorPermissions,
+		},
+		{
+			name:   "owner token after a downgrade to viewer",
+			claims: CustomClaims{Role: models.AdminRoleOwner, Permissions: PermissionsForRole(models.AdminRoleOwner)},
+			role:   models.AdminRoleViewer,
+			want:   readPermissions,
+		},
+		{
+			name:   "editor token after a downgrade to viewer",
+			claims: CustomClaims{Role: models.AdminRoleEditor, Permissions: PermissionsForRole(models.AdminRoleEditor)},
+			role:   models.AdminRoleViewer,
+			want:   readPermissions,
+		},
+		{
+			name:   "viewer token after an upgrade to owner",
+			claims: CustomClaims{Role: models.AdminRoleViewer, Permissions: PermissionsForRole(models.AdminRoleViewer)},
+			role:   models.AdminRoleOwner,
+			want:   readPermissions,
+		},
+		{
+			name:   "narrowed token keeps only what it carried",
+			claims: CustomClaims{Role: models.AdminRoleOwner, Permissions: []string{PermissionContentWrite, PermissionUsersManage}},
+			role:   models.AdminRoleEditor,
+			want:   []string{PermissionContentWrite},
+		},
+		{
+			name:   "unknown permissions are dropped",
+			claims: CustomClaims{Role: models.AdminRoleOwner, Permissions: []string{"everything:manage", PermissionContentRead}},
+			role:   models.AdminRoleOwner,
+			want:   []string{PermissionContentRead},
+		},
+		{
+			name:   "unknown role grants nothing",
+			claims: CustomClaims{Role: models.AdminRoleOwner, Permissions: PermissionsForRole(models.AdminRoleOwner)},
+			role:   models.AdminRole("superuser"),
+			want:   []string{},
+		},
+		{
+			name:   "token from before roles gets the current role",
+			claims: CustomClaims{},
+			role:   models.AdminRoleViewer,
+			want:   readPermissions,
+		},
+		{
+			name:   "role without permissions grants nothing",
+			claims: CustomClaims{Role: models.AdminRoleOwner},
+			role:   models.AdminRoleOwner,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EffectivePermissions(&tt.claims, tt.role)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("EffectivePermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionsForRoleWrites(t *testing.T) {
+	writes := []string{PermissionContentWrite, PermissionAssetsWrite, PermissionMessagesWrite, PermissionUsersManage, PermissionSecurityManage}
+
+	for _, permission := range writes {
+		if HasPermission(PermissionsForRole(models.AdminRoleViewer), permission) {
+			t.Errorf("viewer is granted %s", permission)
+		}
+		if !HasPermission(PermissionsForRole(models.AdminRoleOwner), permission) {
+			t.Errorf("owner is not granted %s", permission)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+// RevocationStore persists revoked token IDs, so the list survives restarts
+type RevocationStore interface {
+	SaveRevokedToken(token models.RevokedToken) error
+	ListRevokedTokens(now time.Time) ([]models.RevokedToken, error)
+	CleanupRevokedTokens(now time.Time) error
+}
+
+// RevocationList tracks access tokens that were revoked before they expired. Lookups only read
+// memory, so checking every request costs no database round trip. Entries are dropped once the
+// token has expired, since it would be rejected anyway.
+type RevocationList struct {
+	mu      sync.RWMutex
+	entries map[string]time.Time
+	store   RevocationStore
+}
+
+// NewRevocationList creates an empty list. store may be nil, in which case revocations only
+// last until the process restarts.
+func NewRevocationList(store RevocationStore) *RevocationList {
+	return &RevocationList{
+		entries: make(map[string]time.Time),
+		store:   store,
+	}
+}
+
+// Revoke adds a token to the list. It is rejected in memory straight away, even if persisting
+// the entry fails.
+func (l *RevocationList) Revoke(token models.RevokedToken) error {
+	if token.TokenID == "" || !token.ExpiresAt.After(time.Now()) {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.entries[token.TokenID] = token.ExpiresAt
+	l.mu.Unlock()
+
+	if l.store == nil {
+		return nil
+	}
+	return l.store.SaveRevokedToken(token)
+}
+
+func (l *RevocationList) IsRevoked(tokenID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, revoked := l.entries[tokenID]
+	return revoked
+}
+
+// Load merges the persisted entries into memory. Running it periodically also picks up tokens
+// revoked by other instances.
+func (l *RevocationList) Load() error {
+	if l.store == nil {
+		return nil
+	}
+
+	tokens, err := l.store.ListRevokedTokens(time.Now())
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, token := range tokens {
+		l.entries[token.TokenID] = token.ExpiresAt
+	}
+
+	return nil
+}
+
+// Prune drops expired entries from memory and from the store
+func (l *RevocationList) Prune() error {
+	now := time.Now()
+
+	l.mu.Lock()
+	for tokenID, expiresAt := range l.entries {
+		if !expiresAt.After(now) {
+			delete(l.entries, tokenID)
+		}
+	}
+	l.mu.Unlock()
+
+	if l.store == nil {
+		return nil
+	}
+	return l.store.CleanupRevokedTokens(now)
+}
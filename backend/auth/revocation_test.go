@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+// memoryRevocationStore keeps revocations in memory, as the database would, so the list can be
+// reloaded into another RevocationList
+type memoryRevocationStore struct {
+	mu     sync.Mutex
+	tokens map[string]models.RevokedToken
+}
+
+func newMemoryRevocationStore() *memoryRevocationStore {
+	return &memoryRevocationStore{tokens: make(map[string]models.RevokedToken)}
+}
+
+func (s *memoryRevocationStore) SaveRevokedToken(token models.RevokedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.TokenID] = token
+	return nil
+}
+
+func (s *memoryRevocationStore) ListRevokedTokens(now time.Time) ([]models.RevokedToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tokens []models.RevokedToken
+	for _, token := range s.tokens {
+		if token.ExpiresAt.After(now) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *memoryRevocationStore) CleanupRevokedTokens(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for tokenID, token := range s.tokens {
+		if !token.ExpiresAt.After(now) {
+			delete(s.tokens, tokenID)
+		}
+	}
+	return nil
+}
+
+func TestRevokeIsPersistedAndReloaded(t *testing.T) {
+	store := newMemoryRevocationStore()
+	list := NewRevocationList(store)
+
+	if err := list.Revoke(models.RevokedToken{TokenID: "revoked", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if !list.IsRevoked("revoked") {
+		t.Error("revoked token is not rejected")
+	}
+	if list.IsRevoked("other") {
+		t.Error("unrelated token is rejected")
+	}
+
+	// A restarted or second instance picks the revocation up from the store
+	reloaded := NewRevocationList(store)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reloaded.IsRevoked("revoked") {
+		t.Error("revocation was not reloaded from the store")
+	}
+}
+
+func TestRevokeIgnoresExpiredTokens(t *testing.T) {
+	store := newMemoryRevocationStore()
+	list := NewRevocationList(store)
+
+	for _, token := range []models.RevokedToken{
+		{TokenID: "", ExpiresAt: time.Now().Add(time.Hour)},
+		{TokenID: "expired", ExpiresAt: time.Now().Add(-time.Second)},
+	} {
+		if err := list.Revoke(token); err != nil {
+			t.Fatalf("Revoke(%q): %v", token.TokenID, err)
+		}
+	}
+
+	if list.IsRevoked("expired") || len(store.tokens) != 0 {
+		t.Error("revocations of expired or unnamed tokens should not be kept")
+	}
+}
+
+func TestPruneRemovesOnlyExpiredEntries(t *testing.T) {
+	now := time.Now()
+	store := newMemoryRevocationStore()
+	list := NewRevocationList(store)
+
+	for _, token := range []models.RevokedToken{
+		{TokenID: "active", ExpiresAt: now.Add(time.Hour)},
+		{TokenID: "expired", ExpiresAt: now.Add(-time.Minute)},
+	} {
+		// Set directly, since Revoke refuses tokens that have already expired
+		list.entries[token.TokenID] = token.ExpiresAt
+		store.tokens[token.TokenID] = token
+	}
+
+	if err := list.Prune(); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	if !list.IsRevoked("active") {
+		t.Error("unexpired revocation was pruned from memory")
+	}
+	if _, kept := list.entries["expired"]; kept {
+		t.Error("expired revocation was kept in memory")
+	}
+	if _, kept := store.tokens["active"]; !kept {
+		t.Error("unexpired revocation was pruned from the store")
+	}
+	if _, kept := store.tokens["expired"]; kept {
+		t.Error("expired revocation was kept in the store")
+	}
+}
+
+func TestValidateAccessTokenRejectsRevokedToken(t *testing.T) {
+	keys, err := LoadKeySet("revocation-test-secret", "", nil)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	service := NewAuthService(keys, NewRevocationList(nil), time.Hour)
+
+	pair, err := service.GenerateTokenPair(1, "admin", models.AdminRoleOwner, "session")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair: %v", err)
+	}
+
+	if _, err := service.ValidateAccessToken(pair.AccessToken); err != nil {
+		t.Fatalf("token rejected before revocation: %v", err)
+	}
+
+	if err := service.RevokeAccessToken(models.RevokedToken{TokenID: pair.AccessTokenID, ExpiresAt: pair.AccessExpiresAt}); err != nil {
+		t.Fatalf("RevokeAccessToken: %v", err)
+	}
+
+	if _, err := service.ValidateAccessToken(pair.AccessToken); err == nil {
+		t.Error("revoked token was accepted")
+	}
+}
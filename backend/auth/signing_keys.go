@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verification
+const minRSAKeyBits = 2048
+
+// legacyKeyID identifies the HS256 JWT_SECRET key. Tokens issued before key IDs were added
+// carry no kid header and are checked against this key.
+const legacyKeyID = "hs256"
+
+// SigningKey is one JWT key. Retired keys only have a public half and are kept so tokens they
+// signed stay valid until they expire.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private interface{}
+	public  interface{}
+}
+
+// CanSign reports whether the private half of the key is available
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are still accepted from
+type KeySet struct {
+	current *SigningKey
+	keys    map[string]*SigningKey
+}
+
+// NewKeySet builds a key set that signs with current. The other keys are only used to verify.
+func NewKeySet(current *SigningKey, verificationKeys ...*SigningKey) (*KeySet, error) {
+	if current == nil || !current.CanSign() {
+		return nil, errors.New("a private key is required to sign tokens")
+	}
+
+	set := &KeySet{
+		current: current,
+		keys:    map[string]*SigningKey{current.ID: current},
+	}
+
+	for _, key := range verificationKeys {
+		if _, exists := set.keys[key.ID]; exists {
+			continue
+		}
+		set.keys[key.ID] = key
+	}
+
+	return set, nil
+}
+
+// NewHMACKey wraps a shared secret as an HS256 key. HMAC keys can sign and verify, and are never
+// published in the JWKS.
+func NewHMACKey(secret string) *SigningKey {
+	return &SigningKey{
+		ID:      legacyKeyID,
+		Method:  jwt.SigningMethodHS256,
+		private: []byte(secret),
+		public:  []byte(secret),
+	}
+}
+
+// LoadSigningKeyFile reads an RSA or Ed25519 key from a PEM file. Private keys may be PKCS#8 or
+// PKCS#1; a PKIX public key gives a verification-only key. The key ID is the RFC 7638 thumbprint
+// of the public key, so the same key always gets the same ID.
+func LoadSigningKeyFile(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s does not contain a PEM block", path)
+	}
+
+	var private, public interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block type %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse key: %w", path, err)
+	}
+
+	if signer, ok := private.(crypto.Signer); ok {
+		public = signer.Public()
+	}
+
+	key := &SigningKey{private: private, public: public}
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%s: RSA keys must be at least %d bits", path, minRSAKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%s: only RSA and Ed25519 keys are supported", path)
+	}
+
+	key.ID = thumbprint(key.jwk())
+	return key, nil
+}
+
+// LoadKeySet builds the key set from configuration. With a signing key file, tokens are signed
+// with that key and the HS256 secret, if set, is only kept to verify tokens issued before the
+// switch. Without one, tokens are signed with the secret.
+func LoadKeySet(secret, signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	var verificationKeys []*SigningKey
+	for _, path := range verificationKeyFiles {
+		key, err := LoadSigningKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	if signingKeyFile == "" {
+		if secret == "" {
+			return nil, errors.New("either a JWT secret or a signing key file is required")
+		}
+		return NewKeySet(NewHMACKey(secret), verificationKeys...)
+	}
+
+	current, err := LoadSigningKeyFile(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret != "" {
+		verificationKeys = append(verificationKeys, NewHMACKey(secret))
+	}
+
+	return NewKeySet(current, verificationKeys...)
+}
+
+// JWK is a public key in JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys tokens may be verified with. HMAC keys are left out, since
+// publishing them would let anyone sign tokens.
+func (s *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range s.keys {
+		jwk := key.jwk()
+		if jwk.KeyType == "" {
+			continue
+		}
+		jwk.KeyID = key.ID
+		jwk.Use = "sig"
+		jwk.Algorithm = key.Method.Alg()
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.current.Method, claims)
+	token.Header["kid"] = s.current.ID
+	return token.SignedString(s.current.private)
+}
+
+// verificationKey picks the key a token claims to be signed with. The algorithm must match the
+// key's, so an RSA public key can never be used as an HMAC secret.
+func (s *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = legacyKeyID
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.public, nil
+}
+
+func (k *SigningKey) jwk() JWK {
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType: "RSA",
+			N:       base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       base64.RawURLEncoding.EncodeToString(pub),
+		}
+	default:
+		return JWK{}
+	}
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint: the SHA-256 of the required members in
+// lexicographic order, which is the field order encoding/json uses for a map
+func thumbprint(jwk JWK) string {
+	members := map[string]string{"kty": jwk.KeyType}
+	switch jwk.KeyType {
+	case "RSA":
+		members["n"] = jwk.N
+		members["e"] = jwk.E
+	case "OKP":
+		members["crv"] = jwk.Curve
+		members["x"] = jwk.X
+	}
+
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// WebAuthnCeremonyTimeout is how long the browser and server allow for a registration or login ceremony
+const WebAuthnCeremonyTimeout = 5 * time.Minute
+
+var ErrWebAuthnCloneWarning = errors.New("authenticator signature counter went backwards, the credential may have been cloned")
+
+// WebAuthnUser adapts an admin and their registered credentials to the webauthn.User interface.
+// Handle is the random user handle authenticators store alongside discoverable credentials.
+type WebAuthnUser struct {
+	AdminID     int
+	Handle      []byte
+	Name        string
+	Credentials []webauthn.Credential
+}
+
+func (u *WebAuthnUser) WebAuthnID() []byte {
+	return u.Handle
+}
+
+func (u *WebAuthnUser) WebAuthnName() string {
+	return u.Name
+}
+
+func (u *WebAuthnUser) WebAuthnDisplayName() string {
+	return u.Name
+}
+
+func (u *WebAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.Credentials
+}
+
+// WebAuthnVerifier runs the registration and assertion ceremonies for passkeys. Credentials
+// must be discoverable and user verification is required, so a passkey stands in for both the
+// password and the second factor.
+type WebAuthnVerifier struct {
+	webAuthn *webauthn.WebAuthn
+}
+
+func NewWebAuthnVerifier(rpID, rpName string, origins []string) (*WebAuthnVerifier, error) {
+	timeout := webauthn.TimeoutConfig{
+		Enforce: true,
+		Timeout: WebAuthnCeremonyTimeout,
+	}
+
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:                  rpID,
+		RPDisplayName:         rpName,
+		RPOrigins:             origins,
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebAuthn configuration: %w", err)
+	}
+
+	return &WebAuthnVerifier{webAuthn: webAuthn}, nil
+}
+
+// BeginRegistration returns the options for navigator.credentials.create() and the session
+// data to keep until the response arrives. Existing credentials are excluded so the same
+// authenticator is not registered twice.
+func (v *WebAuthnVerifier) BeginRegistration(user *WebAuthnUser) (*protocol.CredentialCreation, *webauthn.SessionData, error) {
+	exclusions := webauthn.Credentials(user.Credentials).CredentialDescriptors()
+
+	return v.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+}
+
+// FinishRegistration verifies the authenticator's attestation response and returns the new credential
+func (v *WebAuthnVerifier) FinishRegistration(user *WebAuthnUser, session webauthn.SessionData, response []byte) (*webauthn.Credential, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.webAuthn.CreateCredential(user, session, parsed)
+}
+
+// BeginLogin returns the options for navigator.credentials.get(). No username is needed,
+// since the authenticator reports which credential, and so which admin, it used.
+func (v *WebAuthnVerifier) BeginLogin() (*protocol.CredentialAssertion, *webauthn.SessionData, error) {
+	return v.webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+}
+
+// FinishLogin verifies an assertion response. lookup resolves the owner of the credential from
+// its ID and user handle. The returned credential carries the updated sign count and flags.
+func (v *WebAuthnVerifier) FinishLogin(
+	session webauthn.SessionData,
+	response []byte,
+	lookup func(credentialID, userHandle []byte) (*WebAuthnUser, error),
+) (*WebAuthnUser, *webauthn.Credential, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var owner *WebAuthnUser
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		user, err := lookup(rawID, userHandle)
+		if err != nil {
+			return nil, err
+		}
+		owner = user
+		return user, nil
+	}
+
+	_, credential, err := v.webAuthn.ValidatePasskeyLogin(handler, session, parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if credential.Authenticator.CloneWarning {
+		return owner, nil, ErrWebAuthnCloneWarning
+	}
+
+	return owner, credential, nil
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth/webauthntest"
+)
+
+const (
+	testRPID   = "portfolio.test"
+	testOrigin = "https://portfolio.test"
+)
+
+// registerPasskey runs a registration ceremony for a new user with a software authenticator
+// and returns the user with the credential added
+func registerPasskey(t *testing.T, verifier *WebAuthnVerifier) (*WebAuthnUser, *webauthntest.Authenticator) {
+	t.Helper()
+
+	user := &WebAuthnUser{AdminID: 1, Handle: make([]byte, 32), Name: "admin"}
+	if _, err := rand.Read(user.Handle); err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := webauthntest.NewAuthenticator(testRPID, testOrigin)
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	creation, session, err := verifier.BeginRegistration(user)
+	if err != nil {
+		t.Fatalf("BeginRegistration: %v", err)
+	}
+
+	response, err := authenticator.Register(creation)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	credential, err := verifier.FinishRegistration(user, *session, response)
+	if err != nil {
+		t.Fatalf("FinishRegistration: %v", err)
+	}
+	if !bytes.Equal(credential.ID, authenticator.CredentialID()) {
+		t.Fatalf("registered credential ID %x, want %x", credential.ID, authenticator.CredentialID())
+	}
+
+	user.Credentials = append(user.Credentials, *credential)
+	return user, authenticator
+}
+
+// loginWithPasskey runs a login ceremony, resolving the credential with lookup
+func loginWithPasskey(
+	t *testing.T,
+	verifier *WebAuthnVerifier,
+	authenticator *webauthntest.Authenticator,
+	lookup func(credentialID, userHandle []byte) (*WebAuthnUser, error),
+) (*WebAuthnUser, error) {
+	t.Helper()
+
+	assertion, session, err := verifier.BeginLogin()
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+
+	response, err := authenticator.Login(assertion)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	owner, credential, err := verifier.FinishLogin(*session, response, lookup)
+	if err == nil && credential.Authenticator.SignCount != authenticator.SignCount {
+		t.Errorf("sign count = %d, want %d", credential.Authenticator.SignCount, authenticator.SignCount)
+	}
+	return owner, err
+}
+
+func newTestVerifier(t *testing.T) *WebAuthnVerifier {
+	t.Helper()
+
+	verifier, err := NewWebAuthnVerifier(testRPID, "Portfolio Admin", []string{testOrigin})
+	if err != nil {
+		t.Fatalf("NewWebAuthnVerifier: %v", err)
+	}
+	return verifier
+}
+
+func TestWebAuthnRegistrationAndLogin(t *testing.T) {
+	verifier := newTestVerifier(t)
+	user, authenticator := registerPasskey(t, verifier)
+
+	lookup := func(credentialID, userHandle []byte) (*WebAuthnUser, error) {
+		if !bytes.Equal(credentialID, authenticator.CredentialID()) || !bytes.Equal(userHandle, user.Handle) {
+			return nil, errors.New("unknown credential")
+		}
+		return user, nil
+	}
+
+	owner, err := loginWithPasskey(t, verifier, authenticator, lookup)
+	if err != nil {
+		t.Fatalf("FinishLogin: %v", err)
+	}
+	if owner != user {
+		t.Error("login resolved to the wrong user")
+	}
+}
+
+func TestWebAuthnLoginFailsWhenLookupFails(t *testing.T) {
+	verifier := newTestVerifier(t)
+	_, authenticator := registerPasskey(t, verifier)
+
+	lookupErr := errors.New("database unavailable")
+	_, err := loginWithPasskey(t, verifier, authenticator, func(credentialID, userHandle []byte) (*WebAuthnUser, error) {
+		return nil, lookupErr
+	})
+	if err == nil {
+		t.Fatal("login succeeded without a user")
+	}
+}
+
+func TestWebAuthnLoginWarnsOnSignCountRegression(t *testing.T) {
+	verifier := newTestVerifier(t)
+	user, authenticator := registerPasskey(t, verifier)
+
+	lookup := func(credentialID, userHandle []byte) (*WebAuthnUser, error) {
+		return user, nil
+	}
+
+	// The server has seen count 5, but the next assertion reports 3
+	user.Credentials[0].Authenticator.SignCount = 5
+	authenticator.SignCount = 2
+
+	owner, err := loginWithPasskey(t, verifier, authenticator, lookup)
+	if !errors.Is(err, ErrWebAuthnCloneWarning) {
+		t.Fatalf("got error %v, want ErrWebAuthnCloneWarning", err)
+	}
+	if owner != user {
+		t.Error("clone warning was not attributed to the credential's owner")
+	}
+}
+
+func TestWebAuthnLoginRejectsOtherOrigin(t *testing.T) {
+	verifier := newTestVerifier(t)
+	user, authenticator := registerPasskey(t, verifier)
+
+	// A phishing site relaying the challenge signs for its own origin
+	authenticator.Origin = "https://portfolio.example"
+
+	_, err := loginWithPasskey(t, verifier, authenticator, func(credentialID, userHandle []byte) (*WebAuthnUser, error) {
+		return user, nil
+	})
+	if err == nil {
+		t.Fatal("assertion for another origin was accepted")
+	}
+}
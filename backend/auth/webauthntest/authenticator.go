@@ -0,0 +1,172 @@
+// Package webauthntest provides a software passkey for tests of the WebAuthn ceremonies
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+)
+
+// Authenticator flags, see https://www.w3.org/TR/webauthn/#authdata-flags
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+// Authenticator answers registration and login challenges like a platform authenticator that
+// verified the user, with an ES256 key and no attestation. It holds a single credential.
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// SignCount is incremented before each assertion and reported in it. Lower it to make the
+	// next assertion look like it came from a cloned authenticator.
+	SignCount uint32
+
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+}
+
+func NewAuthenticator(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{RPID: rpID, Origin: origin, key: key, credentialID: credentialID}, nil
+}
+
+// CredentialID is the ID of the credential the authenticator registers
+func (a *Authenticator) CredentialID() []byte {
+	return a.credentialID
+}
+
+// Register creates the credential for a navigator.credentials.create() call and returns the
+// JSON the browser would send back
+func (a *Authenticator) Register(options *protocol.CredentialCreation) ([]byte, error) {
+	switch id := options.Response.User.ID.(type) {
+	case protocol.URLEncodedBase64:
+		a.userHandle = id
+	case []byte:
+		a.userHandle = id
+	default:
+		return nil, fmt.Errorf("unsupported user ID type %T", id)
+	}
+
+	clientData, err := a.clientData(protocol.CreateCeremony, options.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := a.key.PublicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	point := publicKey.Bytes() // 0x04 || x || y
+
+	coseKey, err := webauthncbor.Marshal(map[int]interface{}{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: point[1:33],
+		-3: point[33:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authData(flagUserPresent|flagUserVerified|flagAttestedCredentialData, 0)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, coseKey...)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.credentialJSON(map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"attestationObject": encode(attestationObject),
+	})
+}
+
+// Login signs the challenge of a navigator.credentials.get() call with the registered
+// credential and returns the JSON the browser would send back
+func (a *Authenticator) Login(options *protocol.CredentialAssertion) ([]byte, error) {
+	if a.userHandle == nil {
+		return nil, fmt.Errorf("no credential has been registered")
+	}
+
+	clientData, err := a.clientData(protocol.AssertCeremony, options.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SignCount++
+	authData := a.authData(flagUserPresent|flagUserVerified, a.SignCount)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return a.credentialJSON(map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+func (a *Authenticator) clientData(ceremony protocol.CeremonyType, challenge protocol.URLEncodedBase64) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   encode(challenge),
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+}
+
+// authData is the fixed part of the authenticator data: RP ID hash, flags and sign count
+func (a *Authenticator) authData(flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, signCount)
+}
+
+func (a *Authenticator) credentialJSON(response map[string]string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"id":                      encode(a.credentialID),
+		"rawId":                   encode(a.credentialID),
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]interface{}{},
+		"response":                response,
+	})
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
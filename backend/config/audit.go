@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// AuditConfig controls the persistent audit log of admin API changes
+type AuditConfig struct {
+	// Retention is how long entries are kept; zero keeps them forever
+	Retention time.Duration
+}
+
+func LoadAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		Retention: getEnvDuration("AUDIT_LOG_RETENTION", "2160h"),
+	}
+}
@@ -20,6 +20,15 @@ type Config struct {
 	Port            string
 	RateLimit       *EnhancedRateLimitConfig
 	SecurityHeaders *SecurityHeadersConfig
+	Notifications   *NotificationConfig
+	Images          *ImageConfig
+	Storage         *StorageConfig
+	MFA             *MFAConfig
+	JWT             *JWTConfig
+	LoginProtection *LoginProtectionConfig
+	Audit           *AuditConfig
+	IPAccess        *IPAccessConfig
+	Contact         *ContactConfig
 }
 
 type DatabaseConfig struct {
@@ -91,6 +100,20 @@ func NewConfig() (*Config, error) {
 	config := &Config{
 		Port:            getEnv("PORT", "8080"),
 		SecurityHeaders: initSecurityHeaders(),
+		Notifications:   LoadNotificationConfig(),
+		Images:          LoadImageConfig(),
+		Storage:         LoadStorageConfig(),
+		MFA:             LoadMFAConfig(),
+		JWT:             LoadJWTConfig(),
+		LoginProtection: LoadLoginProtectionConfig(),
+		Audit:           LoadAuditConfig(),
+		Contact:         LoadContactConfig(),
+	}
+
+	// Refuse to start with a list that cannot be parsed rather than leave the API unrestricted
+	config.IPAccess, err = LoadIPAccessConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP access configuration: %w", err)
 	}
 
 	if os.Getenv("TEST_MODE") == "true" {
@@ -103,9 +126,12 @@ func NewConfig() (*Config, error) {
 		log.Printf("Warning: Failed to initialize database: %v", err)
 	}
 
-	config.MinioClient, err = initMinio()
-	if err != nil {
-		log.Printf("Warning: Failed to initialize MinIO: %v", err)
+	// The local storage driver needs no object storage server
+	if config.Storage.Driver == "minio" {
+		config.MinioClient, err = initMinio()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize MinIO: %v", err)
+		}
 	}
 
 	return config, nil
@@ -191,6 +217,21 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvStringList parses a comma-separated list, skipping empty entries
+func getEnvStringList(key string, defaultValue []string) []string {
+	var values []string
+	for _, part := range strings.Split(getEnv(key, ""), ",") {
+		if value := strings.TrimSpace(part); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
 func initSecurityHeaders() *SecurityHeadersConfig {
 	return &SecurityHeadersConfig{
 		Enabled:    getEnvBool("SECURITY_HEADERS_ENABLED", true),
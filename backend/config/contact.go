@@ -0,0 +1,13 @@
+package config
+
+type ContactConfig struct {
+	// FormSecret signs the tokens the contact form is issued. When unset a random key is used,
+	// so tokens stop working on restart and are not accepted by other instances.
+	FormSecret string
+}
+
+func LoadContactConfig() *ContactConfig {
+	return &ContactConfig{
+		FormSecret: getEnv("CONTACT_FORM_SECRET", ""),
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ImageConfig struct {
+	VariantWidths []int
+	WebPVariants  bool
+	JPEGQuality   int
+	// MaxPixels caps width × height of images that are decoded for resizing, since decoding
+	// allocates memory for every pixel however small the file is
+	MaxPixels int
+}
+
+func LoadImageConfig() *ImageConfig {
+	quality := getEnvInt("IMAGE_JPEG_QUALITY", 82)
+	if quality > 100 {
+		quality = 100
+	}
+
+	return &ImageConfig{
+		VariantWidths: getEnvIntList("IMAGE_VARIANT_WIDTHS", []int{480, 960, 1920}),
+		WebPVariants:  getEnvBool("IMAGE_WEBP_VARIANTS", false),
+		JPEGQuality:   quality,
+		MaxPixels:     getEnvInt("IMAGE_MAX_PIXELS", 50_000_000),
+	}
+}
+
+// getEnvIntList parses a comma-separated list of positive integers, returned in ascending order
+func getEnvIntList(key string, defaultValue []int) []int {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	var values []int
+	for _, part := range strings.Split(valueStr, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || value <= 0 {
+			return defaultValue
+		}
+		values = append(values, value)
+	}
+
+	sort.Ints(values)
+	return values
+}
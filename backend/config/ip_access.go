@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+// IPAccessConfig restricts which client IPs may reach the API. Routes under /api/admin use the
+// admin lists and every other route the public ones. A client matching a deny list is always
+// rejected; when an allow list is set, only clients matching it are let through.
+type IPAccessConfig struct {
+	AdminAllow  []*net.IPNet
+	AdminDeny   []*net.IPNet
+	PublicAllow []*net.IPNet
+	PublicDeny  []*net.IPNet
+	// TrustedProxies are the proxies whose X-Forwarded-For header is believed. Unset, none is
+	// and clients are identified by the connecting address.
+	TrustedProxies []string
+	// BlockRefreshInterval is how often temporary blocks are reloaded from the database, to pick
+	// up blocks added on other instances
+	BlockRefreshInterval time.Duration
+}
+
+func LoadIPAccessConfig() (*IPAccessConfig, error) {
+	cfg := &IPAccessConfig{
+		TrustedProxies:       getEnvStringList("TRUSTED_PROXIES", nil),
+		BlockRefreshInterval: getEnvDuration("IP_BLOCK_REFRESH_INTERVAL", "30s"),
+	}
+
+	lists := []struct {
+		key    string
+		target *[]*net.IPNet
+	}{
+		{"ADMIN_IP_ALLOWLIST", &cfg.AdminAllow},
+		{"ADMIN_IP_DENYLIST", &cfg.AdminDeny},
+		{"PUBLIC_IP_ALLOWLIST", &cfg.PublicAllow},
+		{"PUBLIC_IP_DENYLIST", &cfg.PublicDeny},
+	}
+
+	for _, list := range lists {
+		for _, value := range getEnvStringList(list.key, nil) {
+			network, err := utils.ParseNetwork(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", list.key, err)
+			}
+			*list.target = append(*list.target, network)
+		}
+	}
+
+	return cfg, nil
+}
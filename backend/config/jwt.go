@@ -0,0 +1,19 @@
+package config
+
+// JWTConfig selects the keys tokens are signed and verified with. When SigningKeyFile is set,
+// tokens are signed with that RSA or Ed25519 key; otherwise they fall back to HS256 with Secret.
+type JWTConfig struct {
+	Secret         string
+	SigningKeyFile string
+	// VerificationKeyFiles are retired keys, PEM public or private, that tokens are still
+	// accepted from so a key can be rotated without logging everyone out
+	VerificationKeyFiles []string
+}
+
+func LoadJWTConfig() *JWTConfig {
+	return &JWTConfig{
+		Secret:               getEnv("JWT_SECRET", ""),
+		SigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+		VerificationKeyFiles: getEnvStringList("JWT_VERIFICATION_KEY_FILES", nil),
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+// LoginProtectionConfig controls how failed logins slow down and lock out further attempts.
+// Failures are counted separately per username and per client IP.
+type LoginProtectionConfig struct {
+	// MaxFailedAttempts is how many consecutive failures lock out a username or IP
+	MaxFailedAttempts int
+	// LockoutDuration is the first lockout; each failure after it doubles the next one, up to MaxLockoutDuration
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+	// Before the lockout, the wait between attempts starts at BackoffBase and doubles with each failure
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// FailureResetAfter forgets the failure count once no failure has been seen for this long
+	FailureResetAfter time.Duration
+}
+
+func LoadLoginProtectionConfig() *LoginProtectionConfig {
+	return &LoginProtectionConfig{
+		MaxFailedAttempts:  getEnvInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+		LockoutDuration:    getEnvDuration("LOGIN_LOCKOUT_DURATION", "15m"),
+		MaxLockoutDuration: getEnvDuration("LOGIN_MAX_LOCKOUT_DURATION", "24h"),
+		BackoffBase:        getEnvDuration("LOGIN_BACKOFF_BASE", "1s"),
+		BackoffMax:         getEnvDuration("LOGIN_BACKOFF_MAX", "30s"),
+		FailureResetAfter:  getEnvDuration("LOGIN_FAILURE_RESET_AFTER", "24h"),
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+type MFAConfig struct {
+	TOTPIssuer string
+	// WebAuthn relying party settings. The RP ID is the domain passkeys are bound to and every
+	// origin the admin UI is served from must be listed.
+	WebAuthnRPID    string
+	WebAuthnRPName  string
+	WebAuthnOrigins []string
+}
+
+func LoadMFAConfig() *MFAConfig {
+	return &MFAConfig{
+		TOTPIssuer:      getEnv("TOTP_ISSUER", "Portfolio Admin"),
+		WebAuthnRPID:    getEnv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPName:  getEnv("WEBAUTHN_RP_NAME", "Portfolio Admin"),
+		WebAuthnOrigins: getEnvStringList("WEBAUTHN_ORIGINS", []string{"http://localhost:3000"}),
+	}
+}
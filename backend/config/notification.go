@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+type NotificationConfig struct {
+	Driver       string
+	From         string
+	AdminAddress string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPSecurity string
+	SMTPTimeout  time.Duration
+	FileDir      string
+}
+
+func LoadNotificationConfig() *NotificationConfig {
+	return &NotificationConfig{
+		Driver:       getEnv("MAIL_DRIVER", "none"),
+		From:         getEnv("MAIL_FROM", "portfolio@localhost"),
+		AdminAddress: getEnv("MAIL_ADMIN_ADDRESS", ""),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPSecurity: getEnv("SMTP_SECURITY", "starttls"),
+		SMTPTimeout:  getEnvDuration("SMTP_TIMEOUT", "10s"),
+		FileDir:      getEnv("MAIL_FILE_DIR", "./tmp/mail"),
+	}
+}
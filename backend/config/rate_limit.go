@@ -15,6 +15,7 @@ type EnhancedRateLimitConfig struct {
 	API     RateLimit `json:"api"`
 	Public  RateLimit `json:"public"`
 	Admin   RateLimit `json:"admin"`
+	Contact RateLimit `json:"contact"`
 }
 
 type RateLimit struct {
@@ -55,6 +56,10 @@ func LoadRateLimitConfig() *EnhancedRateLimitConfig {
 			Requests: getEnvInt("RATE_LIMIT_ADMIN_REQUESTS", 30),
 			Period:   getEnvDuration("RATE_LIMIT_ADMIN_PERIOD", "1m"),
 		},
+		Contact: RateLimit{
+			Requests: getEnvInt("RATE_LIMIT_CONTACT_REQUESTS", 3),
+			Period:   getEnvDuration("RATE_LIMIT_CONTACT_PERIOD", "10m"),
+		},
 	}
 }
 
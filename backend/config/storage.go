@@ -0,0 +1,15 @@
+package config
+
+type StorageConfig struct {
+	Driver   string
+	Bucket   string
+	LocalDir string
+}
+
+func LoadStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Driver:   getEnv("STORAGE_DRIVER", "minio"),
+		Bucket:   getEnv("MINIO_BUCKET", "portfolio-assets"),
+		LocalDir: getEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+	}
+}
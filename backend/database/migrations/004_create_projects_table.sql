@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(120) UNIQUE NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    summary VARCHAR(500),
+    description TEXT NOT NULL,
+    image_url TEXT,
+    image_alt VARCHAR(255),
+    tech_stack TEXT[] NOT NULL DEFAULT '{}',
+    repository_url TEXT,
+    live_url TEXT,
+    featured BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_projects_slug ON projects(slug);
+CREATE INDEX IF NOT EXISTS idx_projects_featured ON projects(featured);
+CREATE INDEX IF NOT EXISTS idx_projects_sort_order ON projects(sort_order, updated_at);
@@ -0,0 +1,19 @@
+-- status is one of: draft, scheduled, published
+-- A scheduled post becomes publicly visible once published_at has passed
+CREATE TABLE IF NOT EXISTS blog_posts (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(120) UNIQUE NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    excerpt VARCHAR(500),
+    content TEXT NOT NULL,
+    cover_image_url TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    published_at TIMESTAMP WITH TIME ZONE,
+    author_id INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_blog_posts_status CHECK (status IN ('draft', 'scheduled', 'published'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_posts_slug ON blog_posts(slug);
+CREATE INDEX IF NOT EXISTS idx_blog_posts_status_published_at ON blog_posts(status, published_at);
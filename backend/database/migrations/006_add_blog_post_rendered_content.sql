@@ -0,0 +1,7 @@
+-- content holds the Markdown source, content_html the sanitized render served to visitors
+ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS toc JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN blog_posts.content IS 'Markdown source as written by the admin';
+COMMENT ON COLUMN blog_posts.content_html IS 'Sanitized HTML rendered from content';
+COMMENT ON COLUMN blog_posts.toc IS 'Table of contents generated from the headings in content';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS contact_messages (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(254) NOT NULL,
+    subject VARCHAR(200),
+    message TEXT NOT NULL,
+    ip_address INET NOT NULL,
+    user_agent TEXT,
+    handled BOOLEAN NOT NULL DEFAULT FALSE,
+    handled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_handled_created_at ON contact_messages(handled, created_at);
+CREATE INDEX IF NOT EXISTS idx_contact_messages_ip_address ON contact_messages(ip_address);
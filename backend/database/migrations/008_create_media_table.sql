@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS media (
+    id SERIAL PRIMARY KEY,
+    object_key VARCHAR(100) UNIQUE NOT NULL,
+    content_hash CHAR(64) UNIQUE NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    width INTEGER,
+    height INTEGER,
+    alt_text VARCHAR(500),
+    uploaded_by INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_created_at ON media(created_at);
+CREATE INDEX IF NOT EXISTS idx_media_mime_type ON media(mime_type);
@@ -0,0 +1,8 @@
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS created_by INTEGER REFERENCES admins(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_admins_is_active ON admins(is_active);
+
+COMMENT ON COLUMN admins.is_active IS 'Disabled admins cannot log in and their sessions are revoked';
+COMMENT ON COLUMN admins.created_by IS 'Admin who created this account, NULL for the bootstrap admin';
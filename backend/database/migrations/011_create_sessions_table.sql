@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id VARCHAR(32) PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    refresh_token_hash CHAR(64) UNIQUE NOT NULL,
+    user_agent TEXT,
+    ip_address INET,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_admin_id ON sessions(admin_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at);
+
+COMMENT ON COLUMN sessions.id IS 'Random session identifier, carried in the sid claim of access and refresh tokens';
+COMMENT ON COLUMN sessions.refresh_token_hash IS 'SHA-256 of the current refresh token; replaced on every refresh';
+
+-- Sessions replace the single refresh token stored on the admin row
+ALTER TABLE admins DROP COLUMN IF EXISTS current_token;
+ALTER TABLE admins DROP COLUMN IF EXISTS token_expiration;
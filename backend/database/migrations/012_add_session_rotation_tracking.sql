@@ -0,0 +1,7 @@
+-- Each session is a refresh token rotation family. Remembering the token it replaced lets a
+-- concurrent refresh be told apart from an old token being replayed.
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS previous_token_hash CHAR(64);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN sessions.previous_token_hash IS 'SHA-256 of the refresh token replaced by the latest rotation';
+COMMENT ON COLUMN sessions.rotated_at IS 'When the refresh token was last rotated';
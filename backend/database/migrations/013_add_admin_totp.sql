@@ -0,0 +1,17 @@
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64);
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;
+
+COMMENT ON COLUMN admins.totp_secret IS 'Base32 TOTP shared secret; set during enrolment and only enforced once totp_enabled_at is set';
+COMMENT ON COLUMN admins.totp_last_step IS 'Time step of the last accepted TOTP code, so a code cannot be replayed';
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (admin_id, code_hash)
+);
+
+COMMENT ON COLUMN admin_recovery_codes.code_hash IS 'SHA-256 of the normalised recovery code; the codes themselves are only shown once';
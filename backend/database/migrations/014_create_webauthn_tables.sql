@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    credential_id BYTEA UNIQUE NOT NULL,
+    user_handle BYTEA NOT NULL,
+    public_key BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT 'none',
+    aaguid BYTEA,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state BOOLEAN NOT NULL DEFAULT FALSE,
+    name VARCHAR(100) NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_admin_id ON webauthn_credentials(admin_id);
+
+COMMENT ON COLUMN webauthn_credentials.user_handle IS 'Random WebAuthn user handle, shared by every credential of the same admin';
+COMMENT ON COLUMN webauthn_credentials.public_key IS 'COSE encoded credential public key';
+
+-- Challenges are kept server side between the begin and finish steps and can only be used once
+CREATE TABLE IF NOT EXISTS webauthn_ceremonies (
+    id VARCHAR(32) PRIMARY KEY,
+    admin_id INTEGER REFERENCES admins(id) ON DELETE CASCADE,
+    ceremony_type VARCHAR(20) NOT NULL,
+    session_data JSONB NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_ceremonies_expires_at ON webauthn_ceremonies(expires_at);
+
+COMMENT ON COLUMN webauthn_ceremonies.admin_id IS 'Admin registering a credential; NULL for login, where the admin is not known yet';
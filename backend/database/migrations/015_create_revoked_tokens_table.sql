@@ -0,0 +1,16 @@
+-- Access tokens carry a jti. Revoked IDs are kept until the token would have expired anyway.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    admin_id INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    reason VARCHAR(50) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- The session remembers its latest access token so revoking the session can revoke the token too
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS access_token_id VARCHAR(64);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS access_expires_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN sessions.access_token_id IS 'jti of the most recent access token issued for the session';
@@ -0,0 +1,5 @@
+-- Existing admins could already do everything, so they become owners
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'owner'
+    CONSTRAINT chk_admins_role CHECK (role IN ('owner', 'editor', 'viewer'));
+
+COMMENT ON COLUMN admins.role IS 'owner: everything; editor: content, media and messages; viewer: read only';
@@ -0,0 +1,19 @@
+-- Long-lived credentials for machine clients such as CI. Only a SHA-256 hash of the key is kept.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_admin_id ON api_keys(admin_id);
+
+COMMENT ON COLUMN api_keys.key_prefix IS 'Start of the key, shown so admins can tell their keys apart';
+COMMENT ON COLUMN api_keys.scopes IS 'Permissions the key grants, limited further by the owning admin''s role';
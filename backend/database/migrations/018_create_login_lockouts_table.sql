@@ -0,0 +1,16 @@
+-- Consecutive login failures per username and per client IP. Each failure pushes back the next
+-- allowed attempt; enough of them set locked_until.
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    scope VARCHAR(10) NOT NULL CONSTRAINT chk_login_lockouts_scope CHECK (scope IN ('username', 'ip')),
+    subject VARCHAR(255) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (scope, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockouts_last_failed_at ON login_lockouts(last_failed_at);
+
+COMMENT ON COLUMN login_lockouts.subject IS 'The username tried, whether or not it exists, or the client IP';
+COMMENT ON COLUMN login_lockouts.next_attempt_at IS 'Exponential back-off: attempts before this time are rejected';
@@ -0,0 +1,5 @@
+-- Successful logins record which admin signed in, so each admin can review their own history.
+-- Failed attempts leave it NULL, since the username tried may not exist.
+ALTER TABLE login_attempts ADD COLUMN IF NOT EXISTS admin_id INTEGER REFERENCES admins(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_admin_id_time ON login_attempts(admin_id, attempt_at) WHERE admin_id IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Durable record of every change made through the admin API. The actor's username is copied so
+-- entries still make sense after the account is renamed or deleted.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    actor_username VARCHAR(255),
+    api_key_id INTEGER REFERENCES api_keys(id) ON DELETE SET NULL,
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(255),
+    before_data JSONB,
+    after_data JSONB,
+    ip_address INET NOT NULL,
+    user_agent TEXT,
+    request_id VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log(target_type, target_id, created_at);
+
+COMMENT ON COLUMN audit_log.before_data IS 'Fields the change modified, as they were before; NULL for creations';
+COMMENT ON COLUMN audit_log.after_data IS 'Fields the change modified, as they are after; NULL for deletions';
@@ -0,0 +1,14 @@
+-- Temporary blocks added by admins at runtime, on top of the configured IP allow and deny lists
+CREATE TABLE IF NOT EXISTS ip_blocks (
+    id SERIAL PRIMARY KEY,
+    network CIDR NOT NULL,
+    scope VARCHAR(10) NOT NULL DEFAULT 'admin' CONSTRAINT chk_ip_blocks_scope CHECK (scope IN ('admin', 'all')),
+    reason TEXT,
+    created_by INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ip_blocks_expires_at ON ip_blocks(expires_at);
+
+COMMENT ON COLUMN ip_blocks.scope IS 'admin: only /api/admin is blocked; all: every route is blocked';
@@ -1,3 +1,3 @@
-INSERT INTO admins (username, password_hash, password_salt, hash_version, created_at, updated_at) 
-VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-RETURNING id, username, password_hash, password_salt, hash_version, last_login, current_token, token_expiration, created_at, updated_at;
+INSERT INTO admins (username, password_hash, password_salt, hash_version, role, created_by, created_at, updated_at) 
+VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, username, password_hash, password_salt, hash_version, last_login, is_active, disabled_at, role, created_by, password_changed_at, totp_secret, totp_enabled_at, totp_last_step, created_at, updated_at;
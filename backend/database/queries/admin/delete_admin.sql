@@ -0,0 +1 @@
+DELETE FROM admins WHERE id = $1;
@@ -0,0 +1,3 @@
+UPDATE admins
+SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
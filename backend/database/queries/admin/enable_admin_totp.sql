@@ -0,0 +1,3 @@
+UPDATE admins
+SET totp_enabled_at = CURRENT_TIMESTAMP, totp_last_step = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL;
@@ -1,3 +0,0 @@
-SELECT id, username, password_hash, password_salt, hash_version, last_login, current_token, token_expiration, created_at, updated_at
-FROM admins 
-WHERE current_token = $1 AND token_expiration > CURRENT_TIMESTAMP;
@@ -1,3 +1,3 @@
-SELECT id, username, password_hash, password_salt, hash_version, last_login, current_token, token_expiration, created_at, updated_at
+SELECT id, username, password_hash, password_salt, hash_version, last_login, is_active, disabled_at, role, created_by, password_changed_at, totp_secret, totp_enabled_at, totp_last_step, created_at, updated_at
 FROM admins 
 WHERE username = $1;
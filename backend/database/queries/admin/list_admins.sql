@@ -0,0 +1,3 @@
+SELECT id, username, password_hash, password_salt, hash_version, last_login, is_active, disabled_at, role, created_by, password_changed_at, totp_secret, totp_enabled_at, totp_last_step, created_at, updated_at
+FROM admins
+ORDER BY created_at ASC, id ASC;
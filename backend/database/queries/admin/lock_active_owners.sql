@@ -0,0 +1 @@
+SELECT id FROM admins WHERE is_active = TRUE AND role = 'owner' FOR UPDATE;
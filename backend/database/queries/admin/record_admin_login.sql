@@ -0,0 +1,3 @@
+UPDATE admins
+SET last_login = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
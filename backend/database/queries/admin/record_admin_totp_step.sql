@@ -0,0 +1,4 @@
+-- Only moves forward, so each code is accepted at most once
+UPDATE admins
+SET totp_last_step = $2
+WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2);
@@ -0,0 +1,4 @@
+-- Starting enrolment again replaces an unconfirmed secret, but never an active one
+UPDATE admins
+SET totp_secret = $2, totp_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND totp_enabled_at IS NULL;
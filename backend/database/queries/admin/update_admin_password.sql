@@ -0,0 +1,7 @@
+UPDATE admins
+SET password_hash = $1,
+    password_salt = NULL,
+    hash_version = 2,
+    password_changed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2;
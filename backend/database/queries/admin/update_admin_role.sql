@@ -0,0 +1,4 @@
+UPDATE admins
+SET role = $1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $2
+RETURNING id, username, password_hash, password_salt, hash_version, last_login, is_active, disabled_at, role, created_by, password_changed_at, totp_secret, totp_enabled_at, totp_last_step, created_at, updated_at;
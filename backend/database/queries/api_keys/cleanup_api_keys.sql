@@ -0,0 +1,2 @@
+DELETE FROM api_keys
+WHERE revoked_at < $1 OR expires_at < $1;
@@ -0,0 +1,3 @@
+INSERT INTO api_keys (admin_id, name, key_prefix, key_hash, scopes, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+RETURNING id, admin_id, name, key_prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_at;
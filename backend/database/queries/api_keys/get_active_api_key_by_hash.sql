@@ -0,0 +1,3 @@
+SELECT id, admin_id, name, key_prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_at
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP);
@@ -0,0 +1,4 @@
+SELECT id, admin_id, name, key_prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_at
+FROM api_keys
+WHERE admin_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
@@ -0,0 +1,3 @@
+UPDATE api_keys
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE admin_id = $1 AND revoked_at IS NULL;
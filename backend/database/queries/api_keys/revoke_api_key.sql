@@ -0,0 +1,4 @@
+UPDATE api_keys
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND admin_id = $2 AND revoked_at IS NULL
+RETURNING id, admin_id, name, key_prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_at;
@@ -0,0 +1,3 @@
+UPDATE api_keys
+SET last_used_at = CURRENT_TIMESTAMP, last_used_ip = $2
+WHERE id = $1;
@@ -0,0 +1 @@
+DELETE FROM audit_log WHERE created_at < $1;
@@ -0,0 +1,9 @@
+SELECT COUNT(*)
+FROM audit_log
+WHERE ($1::integer IS NULL OR actor_id = $1::integer)
+  AND ($2::text IS NULL OR action = $2::text)
+  AND ($3::text IS NULL OR target_type = $3::text)
+  AND ($4::text IS NULL OR target_id = $4::text)
+  AND ($5::inet IS NULL OR ip_address <<= $5::inet)
+  AND ($6::timestamptz IS NULL OR created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR created_at < $7::timestamptz);
@@ -0,0 +1,2 @@
+INSERT INTO audit_log (actor_id, actor_username, api_key_id, action, target_type, target_id, before_data, after_data, ip_address, user_agent, request_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP);
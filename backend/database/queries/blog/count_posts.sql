@@ -0,0 +1,5 @@
+SELECT COUNT(*) FROM (
+    SELECT CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status
+    FROM blog_posts
+) posts
+WHERE $1::text = '' OR status = $1::text;
@@ -0,0 +1 @@
+SELECT COUNT(*) FROM blog_posts WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
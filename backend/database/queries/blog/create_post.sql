@@ -0,0 +1,5 @@
+INSERT INTO blog_posts (slug, title, excerpt, content, content_html, toc, cover_image_url, status, author_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'draft', $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+          CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+          published_at, author_id, created_at, updated_at;
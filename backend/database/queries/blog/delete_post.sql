@@ -0,0 +1 @@
+DELETE FROM blog_posts WHERE id = $1;
@@ -0,0 +1,5 @@
+SELECT id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+       CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+       published_at, author_id, created_at, updated_at
+FROM blog_posts 
+WHERE id = $1;
@@ -0,0 +1,9 @@
+SELECT * FROM (
+    SELECT id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+       CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+       published_at, author_id, created_at, updated_at
+    FROM blog_posts
+) posts
+WHERE $1::text = '' OR status = $1::text
+ORDER BY updated_at DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,7 @@
+SELECT id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+       CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+       published_at, author_id, created_at, updated_at
+FROM blog_posts 
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+ORDER BY published_at DESC
+LIMIT $1 OFFSET $2;
@@ -0,0 +1,6 @@
+UPDATE blog_posts 
+SET slug = $1, title = $2, excerpt = $3, content = $4, content_html = $5, toc = $6, cover_image_url = $7, updated_at = CURRENT_TIMESTAMP
+WHERE id = $8
+RETURNING id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+          CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+          published_at, author_id, created_at, updated_at;
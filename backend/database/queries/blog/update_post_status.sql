@@ -0,0 +1,6 @@
+UPDATE blog_posts 
+SET status = $1, published_at = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $3
+RETURNING id, slug, title, excerpt, content, content_html, toc, cover_image_url,
+          CASE WHEN status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP THEN 'published' ELSE status END AS status,
+          published_at, author_id, created_at, updated_at;
@@ -0,0 +1,3 @@
+SELECT COUNT(*) 
+FROM contact_messages 
+WHERE $1::boolean IS NULL OR handled = $1::boolean;
@@ -0,0 +1,3 @@
+INSERT INTO contact_messages (name, email, subject, message, ip_address, user_agent, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+RETURNING id, name, email, subject, message, ip_address, user_agent, handled, handled_at, created_at;
@@ -0,0 +1 @@
+DELETE FROM contact_messages WHERE id = $1;
@@ -0,0 +1,3 @@
+SELECT id, name, email, subject, message, ip_address, user_agent, handled, handled_at, created_at
+FROM contact_messages 
+WHERE id = $1;
@@ -0,0 +1,5 @@
+SELECT id, name, email, subject, message, ip_address, user_agent, handled, handled_at, created_at
+FROM contact_messages 
+WHERE $1::boolean IS NULL OR handled = $1::boolean
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,4 @@
+UPDATE contact_messages 
+SET handled = TRUE, handled_at = COALESCE(handled_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING id, name, email, subject, message, ip_address, user_agent, handled, handled_at, created_at;
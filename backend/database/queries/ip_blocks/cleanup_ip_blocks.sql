@@ -0,0 +1 @@
+DELETE FROM ip_blocks WHERE expires_at < $1;
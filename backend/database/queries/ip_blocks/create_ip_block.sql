@@ -0,0 +1,3 @@
+INSERT INTO ip_blocks (network, scope, reason, created_by, expires_at, created_at)
+VALUES ($1::cidr, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+RETURNING id, network, scope, reason, created_by, expires_at, created_at;
@@ -0,0 +1 @@
+DELETE FROM ip_blocks WHERE id = $1;
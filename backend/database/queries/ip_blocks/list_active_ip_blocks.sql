@@ -0,0 +1,4 @@
+SELECT id, network, scope, reason, created_by, expires_at, created_at
+FROM ip_blocks
+WHERE expires_at > $1
+ORDER BY created_at DESC, id DESC;
@@ -1 +1,16 @@
-DELETE FROM login_attempts WHERE attempt_at < $1;
+-- Each admin's latest successful login from an IP is kept, so known locations do not trigger
+-- new login alerts again once their history ages out
+DELETE FROM login_attempts la
+WHERE la.attempt_at < $1
+  AND NOT (
+    la.success = TRUE
+    AND la.admin_id IS NOT NULL
+    AND NOT EXISTS (
+      SELECT 1
+      FROM login_attempts newer
+      WHERE newer.admin_id = la.admin_id
+        AND newer.ip_address = la.ip_address
+        AND newer.success = TRUE
+        AND (newer.attempt_at, newer.id) > (la.attempt_at, la.id)
+    )
+  );
@@ -0,0 +1,6 @@
+SELECT COUNT(*)
+FROM login_attempts
+WHERE ($1::boolean IS NULL OR success = $1::boolean)
+  AND ($2::inet IS NULL OR ip_address <<= $2::inet)
+  AND ($3::timestamptz IS NULL OR attempt_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR attempt_at < $4::timestamptz);
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM login_attempts
+WHERE ip_address = $1 AND admin_id = $2 AND success = TRUE;
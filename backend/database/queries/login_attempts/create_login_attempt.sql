@@ -1,2 +1,2 @@
-INSERT INTO login_attempts (ip_address, user_agent, success, attempt_at, details)
-VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4);
+INSERT INTO login_attempts (ip_address, user_agent, success, attempt_at, details, admin_id)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4, $5);
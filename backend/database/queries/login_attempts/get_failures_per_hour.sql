@@ -0,0 +1,5 @@
+SELECT date_trunc('hour', attempt_at) AS hour, COUNT(*)
+FROM login_attempts
+WHERE success = FALSE AND attempt_at >= $1 AND attempt_at < $2
+GROUP BY hour
+ORDER BY hour;
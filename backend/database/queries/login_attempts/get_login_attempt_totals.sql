@@ -0,0 +1,6 @@
+SELECT COUNT(*),
+       COUNT(*) FILTER (WHERE success = FALSE),
+       COUNT(DISTINCT ip_address),
+       COUNT(DISTINCT user_agent)
+FROM login_attempts
+WHERE attempt_at >= $1 AND attempt_at < $2;
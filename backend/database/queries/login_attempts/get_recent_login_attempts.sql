@@ -1,4 +1,4 @@
-SELECT id, ip_address, user_agent, success, attempt_at, details
+SELECT id, ip_address, user_agent, success, attempt_at, details, admin_id
 FROM login_attempts 
 WHERE ip_address = $1 AND attempt_at >= $2
 ORDER BY attempt_at DESC;
@@ -0,0 +1,6 @@
+SELECT ip_address, COUNT(*) AS failures, MAX(attempt_at)
+FROM login_attempts
+WHERE success = FALSE AND attempt_at >= $1 AND attempt_at < $2
+GROUP BY ip_address
+ORDER BY failures DESC, ip_address
+LIMIT $3;
@@ -0,0 +1,8 @@
+SELECT COALESCE(user_agent, ''),
+       COUNT(*) AS attempts,
+       COUNT(*) FILTER (WHERE success = FALSE)
+FROM login_attempts
+WHERE attempt_at >= $1 AND attempt_at < $2
+GROUP BY COALESCE(user_agent, '')
+ORDER BY attempts DESC, 1
+LIMIT $3;
@@ -0,0 +1,5 @@
+SELECT id, ip_address, user_agent, success, attempt_at, details, admin_id
+FROM login_attempts
+WHERE admin_id = $1 AND success = TRUE
+ORDER BY attempt_at DESC, id DESC
+LIMIT $2;
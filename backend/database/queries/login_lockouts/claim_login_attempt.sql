@@ -0,0 +1,9 @@
+-- Takes the next attempt for a username or IP, holding off parallel attempts until its outcome
+-- is recorded. Returns no row while a back-off, lockout or another attempt's hold is in force.
+INSERT INTO login_lockouts (scope, subject, failed_attempts, last_failed_at, next_attempt_at)
+VALUES ($1, $2, 0, $3, $4)
+ON CONFLICT (scope, subject) DO UPDATE
+SET next_attempt_at = EXCLUDED.next_attempt_at
+WHERE (login_lockouts.next_attempt_at IS NULL OR login_lockouts.next_attempt_at <= $3)
+  AND (login_lockouts.locked_until IS NULL OR login_lockouts.locked_until <= $3)
+RETURNING scope, subject, failed_attempts, last_failed_at, next_attempt_at, locked_until;
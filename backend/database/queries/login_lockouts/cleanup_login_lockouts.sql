@@ -0,0 +1,2 @@
+DELETE FROM login_lockouts
+WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < $2);
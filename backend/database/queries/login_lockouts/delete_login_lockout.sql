@@ -0,0 +1,2 @@
+DELETE FROM login_lockouts
+WHERE scope = $1 AND subject = $2;
@@ -0,0 +1,3 @@
+SELECT scope, subject, failed_attempts, last_failed_at, next_attempt_at, locked_until
+FROM login_lockouts
+WHERE (scope = 'username' AND subject = $1) OR (scope = 'ip' AND subject = $2);
@@ -0,0 +1,4 @@
+SELECT scope, subject, failed_attempts, last_failed_at, next_attempt_at, locked_until
+FROM login_lockouts
+WHERE failed_attempts > 0 AND (locked_until > $1 OR next_attempt_at > $1)
+ORDER BY COALESCE(locked_until, next_attempt_at) DESC;
@@ -0,0 +1,9 @@
+INSERT INTO login_lockouts (scope, subject, failed_attempts, last_failed_at)
+VALUES ($1, $2, 1, $3)
+ON CONFLICT (scope, subject) DO UPDATE
+SET failed_attempts = CASE
+        WHEN login_lockouts.last_failed_at < $4 THEN 1
+        ELSE login_lockouts.failed_attempts + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING scope, subject, failed_attempts, last_failed_at, next_attempt_at, locked_until;
@@ -0,0 +1,4 @@
+-- Only lifts the hold if no other attempt has replaced it since
+UPDATE login_lockouts
+SET next_attempt_at = NULL
+WHERE scope = $1 AND subject = $2 AND next_attempt_at = $3;
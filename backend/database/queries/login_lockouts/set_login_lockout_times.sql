@@ -0,0 +1,3 @@
+UPDATE login_lockouts
+SET next_attempt_at = $3, locked_until = $4
+WHERE scope = $1 AND subject = $2;
@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+FROM media
+WHERE ($1::text = '' OR filename ILIKE '%' || $1::text || '%' OR alt_text ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR mime_type = $2::text);
@@ -0,0 +1,3 @@
+INSERT INTO media (object_key, content_hash, filename, mime_type, size_bytes, width, height, alt_text, uploaded_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP)
+RETURNING id, object_key, content_hash, filename, mime_type, size_bytes, width, height, alt_text, uploaded_by, created_at;
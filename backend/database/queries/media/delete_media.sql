@@ -0,0 +1 @@
+DELETE FROM media WHERE id = $1;
@@ -0,0 +1,3 @@
+SELECT id, object_key, content_hash, filename, mime_type, size_bytes, width, height, alt_text, uploaded_by, created_at
+FROM media
+WHERE content_hash = $1;
@@ -0,0 +1,6 @@
+SELECT id, object_key, content_hash, filename, mime_type, size_bytes, width, height, alt_text, uploaded_by, created_at
+FROM media
+WHERE ($1::text = '' OR filename ILIKE '%' || $1::text || '%' OR alt_text ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR mime_type = $2::text)
+ORDER BY created_at DESC, id DESC
+LIMIT $3 OFFSET $4;
@@ -0,0 +1,2 @@
+DELETE FROM password_reset_tokens
+WHERE expires_at < $1 OR used_at < $1;
@@ -0,0 +1,5 @@
+-- Marking the token used in the same statement that checks it makes it single-use under concurrency
+UPDATE password_reset_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+RETURNING id, admin_id, created_by, expires_at, used_at, created_at;
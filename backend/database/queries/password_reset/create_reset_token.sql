@@ -0,0 +1,3 @@
+INSERT INTO password_reset_tokens (admin_id, token_hash, created_by, expires_at, created_at)
+VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+RETURNING id, admin_id, created_by, expires_at, used_at, created_at;
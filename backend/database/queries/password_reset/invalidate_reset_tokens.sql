@@ -0,0 +1,3 @@
+UPDATE password_reset_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE admin_id = $1 AND used_at IS NULL;
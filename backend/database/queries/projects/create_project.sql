@@ -0,0 +1,3 @@
+INSERT INTO projects (slug, title, summary, description, image_url, image_alt, tech_stack, repository_url, live_url, featured, sort_order, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, slug, title, summary, description, image_url, image_alt, tech_stack, repository_url, live_url, featured, sort_order, created_at, updated_at;
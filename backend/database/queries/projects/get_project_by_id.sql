@@ -0,0 +1,3 @@
+SELECT id, slug, title, summary, description, image_url, image_alt, tech_stack, repository_url, live_url, featured, sort_order, created_at, updated_at
+FROM projects 
+WHERE id = $1;
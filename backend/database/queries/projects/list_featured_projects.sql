@@ -0,0 +1,4 @@
+SELECT id, slug, title, summary, description, image_url, image_alt, tech_stack, repository_url, live_url, featured, sort_order, created_at, updated_at
+FROM projects 
+WHERE featured = TRUE
+ORDER BY sort_order ASC, updated_at DESC;
@@ -0,0 +1,5 @@
+UPDATE projects 
+SET slug = $1, title = $2, summary = $3, description = $4, image_url = $5, image_alt = $6, tech_stack = $7,
+    repository_url = $8, live_url = $9, featured = $10, sort_order = $11, updated_at = CURRENT_TIMESTAMP
+WHERE id = $12
+RETURNING id, slug, title, summary, description, image_url, image_alt, tech_stack, repository_url, live_url, featured, sort_order, created_at, updated_at;
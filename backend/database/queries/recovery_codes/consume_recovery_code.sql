@@ -0,0 +1,3 @@
+UPDATE admin_recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL;
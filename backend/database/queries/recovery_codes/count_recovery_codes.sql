@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM admin_recovery_codes
+WHERE admin_id = $1 AND used_at IS NULL;
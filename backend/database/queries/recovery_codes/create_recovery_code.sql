@@ -0,0 +1,2 @@
+INSERT INTO admin_recovery_codes (admin_id, code_hash, created_at)
+VALUES ($1, $2, CURRENT_TIMESTAMP);
@@ -0,0 +1,2 @@
+DELETE FROM admin_recovery_codes
+WHERE admin_id = $1;
@@ -0,0 +1,3 @@
+INSERT INTO revoked_tokens (jti, admin_id, reason, expires_at, revoked_at)
+VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+ON CONFLICT (jti) DO NOTHING;
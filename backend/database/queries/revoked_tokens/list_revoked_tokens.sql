@@ -0,0 +1,3 @@
+SELECT jti, admin_id, reason, expires_at, revoked_at
+FROM revoked_tokens
+WHERE expires_at > $1;
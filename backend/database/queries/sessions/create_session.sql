@@ -0,0 +1,3 @@
+INSERT INTO sessions (id, admin_id, refresh_token_hash, user_agent, ip_address, expires_at, access_token_id, access_expires_at, created_at, last_used_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, admin_id, refresh_token_hash, previous_token_hash, rotated_at, access_token_id, access_expires_at, user_agent, host(ip_address), created_at, last_used_at, expires_at, revoked_at;
@@ -0,0 +1,4 @@
+SELECT id, admin_id, refresh_token_hash, previous_token_hash, rotated_at, access_token_id, access_expires_at, user_agent, host(ip_address), created_at, last_used_at, expires_at, revoked_at
+FROM sessions
+WHERE admin_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+ORDER BY last_used_at DESC;
@@ -0,0 +1,4 @@
+UPDATE sessions
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE admin_id = $1 AND id <> $2 AND revoked_at IS NULL
+RETURNING id, admin_id, refresh_token_hash, previous_token_hash, rotated_at, access_token_id, access_expires_at, user_agent, host(ip_address), created_at, last_used_at, expires_at, revoked_at;
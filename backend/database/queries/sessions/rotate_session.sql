@@ -0,0 +1,7 @@
+-- Matching on the old hash means two concurrent refreshes with the same token cannot both succeed
+UPDATE sessions
+SET previous_token_hash = refresh_token_hash, rotated_at = CURRENT_TIMESTAMP,
+    refresh_token_hash = $3, expires_at = $4, user_agent = $5, ip_address = $6,
+    access_token_id = $7, access_expires_at = $8, last_used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+RETURNING id, admin_id, refresh_token_hash, previous_token_hash, rotated_at, access_token_id, access_expires_at, user_agent, host(ip_address), created_at, last_used_at, expires_at, revoked_at;
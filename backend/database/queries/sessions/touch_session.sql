@@ -0,0 +1,3 @@
+UPDATE sessions
+SET last_used_at = CURRENT_TIMESTAMP, ip_address = $2
+WHERE id = $1 AND revoked_at IS NULL;
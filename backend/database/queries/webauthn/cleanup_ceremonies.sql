@@ -0,0 +1,2 @@
+DELETE FROM webauthn_ceremonies
+WHERE expires_at < $1;
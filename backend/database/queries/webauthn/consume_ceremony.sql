@@ -0,0 +1,4 @@
+-- Deleting as it is read makes each challenge single use
+DELETE FROM webauthn_ceremonies
+WHERE id = $1 AND ceremony_type = $2 AND expires_at > CURRENT_TIMESTAMP
+RETURNING admin_id, session_data;
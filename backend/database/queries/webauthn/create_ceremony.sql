@@ -0,0 +1,2 @@
+INSERT INTO webauthn_ceremonies (id, admin_id, ceremony_type, session_data, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP);
@@ -0,0 +1,3 @@
+INSERT INTO webauthn_credentials (admin_id, credential_id, user_handle, public_key, attestation_type, aaguid, sign_count, transports, backup_eligible, backup_state, name, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP)
+RETURNING id, admin_id, credential_id, user_handle, public_key, attestation_type, aaguid, sign_count, transports, backup_eligible, backup_state, name, last_used_at, created_at;
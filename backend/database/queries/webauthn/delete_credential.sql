@@ -0,0 +1,2 @@
+DELETE FROM webauthn_credentials
+WHERE id = $1 AND admin_id = $2;
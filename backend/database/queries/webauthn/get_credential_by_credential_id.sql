@@ -0,0 +1,3 @@
+SELECT id, admin_id, credential_id, user_handle, public_key, attestation_type, aaguid, sign_count, transports, backup_eligible, backup_state, name, last_used_at, created_at
+FROM webauthn_credentials
+WHERE credential_id = $1;
@@ -0,0 +1,4 @@
+SELECT id, admin_id, credential_id, user_handle, public_key, attestation_type, aaguid, sign_count, transports, backup_eligible, backup_state, name, last_used_at, created_at
+FROM webauthn_credentials
+WHERE admin_id = $1
+ORDER BY created_at ASC;
@@ -0,0 +1,3 @@
+UPDATE webauthn_credentials
+SET sign_count = $2, backup_state = $3, last_used_at = CURRENT_TIMESTAMP
+WHERE id = $1;
@@ -75,41 +75,313 @@ func (ql *QueryLoader) ListQueries() []string {
 }
 
 type AdminQueries struct {
-	GetAdminByUsername   string
-	GetAdminByID         string
-	CreateAdmin          string
-	UpdateAdminToken     string
-	InvalidateAdminToken string
-	GetAdminByToken      string
-	CountAdmins          string
-	CleanupExpiredTokens string
+	GetAdminByUsername  string
+	GetAdminByID        string
+	CreateAdmin         string
+	CountAdmins         string
+	ListAdmins          string
+	UpdateAdminUsername string
+	UpdateAdminRole     string
+	SetAdminActive      string
+	LockActiveOwners    string
+	DeleteAdmin         string
+	UpdateAdminPassword string
+	RecordAdminLogin    string
+	SetAdminTOTPSecret  string
+	EnableAdminTOTP     string
+	DisableAdminTOTP    string
+	RecordAdminTOTPStep string
+}
+
+type APIKeyQueries struct {
+	CreateAPIKey          string
+	GetActiveAPIKeyByHash string
+	ListAPIKeys           string
+	TouchAPIKey           string
+	RevokeAPIKey          string
+	RevokeAllAPIKeys      string
+	CleanupAPIKeys        string
+}
+
+type RevokedTokenQueries struct {
+	CreateRevokedToken   string
+	ListRevokedTokens    string
+	CleanupRevokedTokens string
+}
+
+type RecoveryCodeQueries struct {
+	CreateRecoveryCode  string
+	DeleteRecoveryCodes string
+	ConsumeRecoveryCode string
+	CountRecoveryCodes  string
+}
+
+type WebAuthnQueries struct {
+	CreateCredential            string
+	ListCredentials             string
+	GetCredentialByCredentialID string
+	RecordCredentialUse         string
+	DeleteCredential            string
+	CreateCeremony              string
+	ConsumeCeremony             string
+	CleanupCeremonies           string
+}
+
+type SessionQueries struct {
+	CreateSession          string
+	GetActiveSession       string
+	RotateSession          string
+	TouchSession           string
+	ListActiveSessions     string
+	RevokeSession          string
+	RevokeOtherSessions    string
+	RevokeAllSessions      string
+	CleanupExpiredSessions string
+}
+
+type PasswordResetQueries struct {
+	CreateResetToken      string
+	ConsumeResetToken     string
+	InvalidateResetTokens string
+	CleanupResetTokens    string
 }
 
 type LoginAttemptQueries struct {
-	CreateLoginAttempt      string
-	GetRecentLoginAttempts  string
-	GetFailedLoginAttempts  string
-	CleanupOldLoginAttempts string
+	CreateLoginAttempt          string
+	GetRecentLoginAttempts      string
+	GetFailedLoginAttempts      string
+	CleanupOldLoginAttempts     string
+	CountSuccessfulLogins       string
+	ListLoginAttempts           string
+	CountLoginAttempts          string
+	ListSuccessfulLoginsByAdmin string
+	GetLoginAttemptTotals       string
+	GetFailuresPerHour          string
+	GetTopFailedIPs             string
+	GetUserAgentCounts          string
+}
+
+type LoginLockoutQueries struct {
+	GetLoginLockouts        string
+	ClaimLoginAttempt       string
+	ReleaseLoginAttempt     string
+	RecordLoginFailure      string
+	SetLoginLockoutTimes    string
+	DeleteLoginLockout      string
+	ListActiveLoginLockouts string
+	CleanupLoginLockouts    string
+}
+
+type AuditLogQueries struct {
+	CreateAuditEntry  string
+	ListAuditEntries  string
+	CountAuditEntries string
+	CleanupAuditLog   string
+}
+
+type IPBlockQueries struct {
+	CreateIPBlock      string
+	ListActiveIPBlocks string
+	DeleteIPBlock      string
+	CleanupIPBlocks    string
+}
+
+type ProjectQueries struct {
+	ListProjects         string
+	ListFeaturedProjects string
+	GetProjectBySlug     string
+	GetProjectByID       string
+	CreateProject        string
+	UpdateProject        string
+	DeleteProject        string
+}
+
+type BlogQueries struct {
+	ListPublishedPosts     string
+	CountPublishedPosts    string
+	GetPublishedPostBySlug string
+	ListPosts              string
+	CountPosts             string
+	GetPostByID            string
+	CreatePost             string
+	UpdatePost             string
+	UpdatePostStatus       string
+	DeletePost             string
+}
+
+type ContactQueries struct {
+	CreateMessage      string
+	ListMessages       string
+	CountMessages      string
+	GetMessageByID     string
+	MarkMessageHandled string
+	DeleteMessage      string
+}
+
+type MediaQueries struct {
+	CreateMedia    string
+	GetMediaByID   string
+	GetMediaByKey  string
+	GetMediaByHash string
+	ListMedia      string
+	CountMedia     string
+	DeleteMedia    string
 }
 
 var QueryKeys = struct {
-	Admin        AdminQueries
-	LoginAttempt LoginAttemptQueries
+	Admin         AdminQueries
+	RecoveryCode  RecoveryCodeQueries
+	RevokedToken  RevokedTokenQueries
+	APIKey        APIKeyQueries
+	WebAuthn      WebAuthnQueries
+	PasswordReset PasswordResetQueries
+	Session       SessionQueries
+	LoginAttempt  LoginAttemptQueries
+	LoginLockout  LoginLockoutQueries
+	AuditLog      AuditLogQueries
+	IPBlock       IPBlockQueries
+	Project       ProjectQueries
+	Blog          BlogQueries
+	Contact       ContactQueries
+	Media         MediaQueries
 }{
 	Admin: AdminQueries{
-		GetAdminByUsername:   "admin.get_admin_by_username",
-		GetAdminByID:         "admin.get_admin_by_id",
-		CreateAdmin:          "admin.create_admin",
-		UpdateAdminToken:     "admin.update_admin_token",
-		InvalidateAdminToken: "admin.invalidate_admin_token",
-		GetAdminByToken:      "admin.get_admin_by_token",
-		CountAdmins:          "admin.count_admins",
-		CleanupExpiredTokens: "admin.cleanup_expired_tokens",
+		GetAdminByUsername:  "admin.get_admin_by_username",
+		GetAdminByID:        "admin.get_admin_by_id",
+		CreateAdmin:         "admin.create_admin",
+		CountAdmins:         "admin.count_admins",
+		ListAdmins:          "admin.list_admins",
+		UpdateAdminUsername: "admin.update_admin_username",
+		UpdateAdminRole:     "admin.update_admin_role",
+		SetAdminActive:      "admin.set_admin_active",
+		LockActiveOwners:    "admin.lock_active_owners",
+		DeleteAdmin:         "admin.delete_admin",
+		UpdateAdminPassword: "admin.update_admin_password",
+		RecordAdminLogin:    "admin.record_admin_login",
+		SetAdminTOTPSecret:  "admin.set_admin_totp_secret",
+		EnableAdminTOTP:     "admin.enable_admin_totp",
+		DisableAdminTOTP:    "admin.disable_admin_totp",
+		RecordAdminTOTPStep: "admin.record_admin_totp_step",
+	},
+	RevokedToken: RevokedTokenQueries{
+		CreateRevokedToken:   "revoked_tokens.create_revoked_token",
+		ListRevokedTokens:    "revoked_tokens.list_revoked_tokens",
+		CleanupRevokedTokens: "revoked_tokens.cleanup_revoked_tokens",
+	},
+	APIKey: APIKeyQueries{
+		CreateAPIKey:          "api_keys.create_api_key",
+		GetActiveAPIKeyByHash: "api_keys.get_active_api_key_by_hash",
+		ListAPIKeys:           "api_keys.list_api_keys",
+		TouchAPIKey:           "api_keys.touch_api_key",
+		RevokeAPIKey:          "api_keys.revoke_api_key",
+		RevokeAllAPIKeys:      "api_keys.revoke_all_api_keys",
+		CleanupAPIKeys:        "api_keys.cleanup_api_keys",
+	},
+	RecoveryCode: RecoveryCodeQueries{
+		CreateRecoveryCode:  "recovery_codes.create_recovery_code",
+		DeleteRecoveryCodes: "recovery_codes.delete_recovery_codes",
+		ConsumeRecoveryCode: "recovery_codes.consume_recovery_code",
+		CountRecoveryCodes:  "recovery_codes.count_recovery_codes",
+	},
+	WebAuthn: WebAuthnQueries{
+		CreateCredential:            "webauthn.create_credential",
+		ListCredentials:             "webauthn.list_credentials",
+		GetCredentialByCredentialID: "webauthn.get_credential_by_credential_id",
+		RecordCredentialUse:         "webauthn.record_credential_use",
+		DeleteCredential:            "webauthn.delete_credential",
+		CreateCeremony:              "webauthn.create_ceremony",
+		ConsumeCeremony:             "webauthn.consume_ceremony",
+		CleanupCeremonies:           "webauthn.cleanup_ceremonies",
+	},
+	Session: SessionQueries{
+		CreateSession:          "sessions.create_session",
+		GetActiveSession:       "sessions.get_active_session",
+		RotateSession:          "sessions.rotate_session",
+		TouchSession:           "sessions.touch_session",
+		ListActiveSessions:     "sessions.list_active_sessions",
+		RevokeSession:          "sessions.revoke_session",
+		RevokeOtherSessions:    "sessions.revoke_other_sessions",
+		RevokeAllSessions:      "sessions.revoke_all_sessions",
+		CleanupExpiredSessions: "sessions.cleanup_expired_sessions",
+	},
+	PasswordReset: PasswordResetQueries{
+		CreateResetToken:      "password_reset.create_reset_token",
+		ConsumeResetToken:     "password_reset.consume_reset_token",
+		InvalidateResetTokens: "password_reset.invalidate_reset_tokens",
+		CleanupResetTokens:    "password_reset.cleanup_reset_tokens",
 	},
 	LoginAttempt: LoginAttemptQueries{
-		CreateLoginAttempt:      "login_attempts.create_login_attempt",
-		GetRecentLoginAttempts:  "login_attempts.get_recent_login_attempts",
-		GetFailedLoginAttempts:  "login_attempts.get_failed_login_attempts",
-		CleanupOldLoginAttempts: "login_attempts.cleanup_old_login_attempts",
+		CreateLoginAttempt:          "login_attempts.create_login_attempt",
+		GetRecentLoginAttempts:      "login_attempts.get_recent_login_attempts",
+		GetFailedLoginAttempts:      "login_attempts.get_failed_login_attempts",
+		CleanupOldLoginAttempts:     "login_attempts.cleanup_old_login_attempts",
+		CountSuccessfulLogins:       "login_attempts.count_successful_logins_from_ip",
+		ListLoginAttempts:           "login_attempts.list_login_attempts",
+		CountLoginAttempts:          "login_attempts.count_login_attempts",
+		ListSuccessfulLoginsByAdmin: "login_attempts.list_successful_logins_by_admin",
+		GetLoginAttemptTotals:       "login_attempts.get_login_attempt_totals",
+		GetFailuresPerHour:          "login_attempts.get_failures_per_hour",
+		GetTopFailedIPs:             "login_attempts.get_top_failed_ips",
+		GetUserAgentCounts:          "login_attempts.get_user_agent_counts",
+	},
+	LoginLockout: LoginLockoutQueries{
+		GetLoginLockouts:        "login_lockouts.get_login_lockouts",
+		ClaimLoginAttempt:       "login_lockouts.claim_login_attempt",
+		ReleaseLoginAttempt:     "login_lockouts.release_login_attempt",
+		RecordLoginFailure:      "login_lockouts.record_login_failure",
+		SetLoginLockoutTimes:    "login_lockouts.set_login_lockout_times",
+		DeleteLoginLockout:      "login_lockouts.delete_login_lockout",
+		ListActiveLoginLockouts: "login_lockouts.list_active_login_lockouts",
+		CleanupLoginLockouts:    "login_lockouts.cleanup_login_lockouts",
+	},
+	AuditLog: AuditLogQueries{
+		CreateAuditEntry:  "audit_log.create_audit_entry",
+		ListAuditEntries:  "audit_log.list_audit_entries",
+		CountAuditEntries: "audit_log.count_audit_entries",
+		CleanupAuditLog:   "audit_log.cleanup_audit_log",
+	},
+	IPBlock: IPBlockQueries{
+		CreateIPBlock:      "ip_blocks.create_ip_block",
+		ListActiveIPBlocks: "ip_blocks.list_active_ip_blocks",
+		DeleteIPBlock:      "ip_blocks.delete_ip_block",
+		CleanupIPBlocks:    "ip_blocks.cleanup_ip_blocks",
+	},
+	Project: ProjectQueries{
+		ListProjects:         "projects.list_projects",
+		ListFeaturedProjects: "projects.list_featured_projects",
+		GetProjectBySlug:     "projects.get_project_by_slug",
+		GetProjectByID:       "projects.get_project_by_id",
+		CreateProject:        "projects.create_project",
+		UpdateProject:        "projects.update_project",
+		DeleteProject:        "projects.delete_project",
+	},
+	Blog: BlogQueries{
+		ListPublishedPosts:     "blog.list_published_posts",
+		CountPublishedPosts:    "blog.count_published_posts",
+		GetPublishedPostBySlug: "blog.get_published_post_by_slug",
+		ListPosts:              "blog.list_posts",
+		CountPosts:             "blog.count_posts",
+		GetPostByID:            "blog.get_post_by_id",
+		CreatePost:             "blog.create_post",
+		UpdatePost:             "blog.update_post",
+		UpdatePostStatus:       "blog.update_post_status",
+		DeletePost:             "blog.delete_post",
+	},
+	Contact: ContactQueries{
+		CreateMessage:      "contact.create_message",
+		ListMessages:       "contact.list_messages",
+		CountMessages:      "contact.count_messages",
+		GetMessageByID:     "contact.get_message_by_id",
+		MarkMessageHandled: "contact.mark_message_handled",
+		DeleteMessage:      "contact.delete_message",
+	},
+	Media: MediaQueries{
+		CreateMedia:    "media.create_media",
+		GetMediaByID:   "media.get_media_by_id",
+		GetMediaByKey:  "media.get_media_by_key",
+		GetMediaByHash: "media.get_media_by_hash",
+		ListMedia:      "media.list_media",
+		CountMedia:     "media.count_media",
+		DeleteMedia:    "media.delete_media",
 	},
 }
@@ -1,54 +1,68 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/Wildcard209/portfolio-webapplication/auth"
 	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/notify"
 	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
 	"github.com/Wildcard209/portfolio-webapplication/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type AdminHandler struct {
-	authService         *auth.AuthService
-	adminRepo           *repository.AdminRepository
-	loginAttemptRepo    *repository.LoginAttemptRepository
-	inputSanitizer      *utils.InputSanitizer
-	errorHandler        *utils.ErrorHandler
-	maxFailedAttempts   int
-	lockoutDuration     time.Duration
-	failedAttemptWindow time.Duration
+	authService      *auth.AuthService
+	adminRepo        *repository.AdminRepository
+	sessionService   *services.SessionService
+	mfaService       *services.MFAService
+	loginAttemptRepo *repository.LoginAttemptRepository
+	loginProtection  *services.LoginProtectionService
+	inputSanitizer   *utils.InputSanitizer
+	errorHandler     *utils.ErrorHandler
+	securityLogger   *utils.SecurityLogger
+	notifier         *notify.Notifier
 }
 
 func NewAdminHandler(
 	authService *auth.AuthService,
 	adminRepo *repository.AdminRepository,
+	sessionService *services.SessionService,
+	mfaService *services.MFAService,
 	loginAttemptRepo *repository.LoginAttemptRepository,
+	loginProtection *services.LoginProtectionService,
+	notifier *notify.Notifier,
 ) *AdminHandler {
 	return &AdminHandler{
-		authService:         authService,
-		adminRepo:           adminRepo,
-		loginAttemptRepo:    loginAttemptRepo,
-		inputSanitizer:      utils.NewInputSanitizer(1000),
-		errorHandler:        utils.NewErrorHandler(),
-		maxFailedAttempts:   5,
-		lockoutDuration:     15 * time.Minute,
-		failedAttemptWindow: 5 * time.Minute,
+		authService:      authService,
+		adminRepo:        adminRepo,
+		sessionService:   sessionService,
+		mfaService:       mfaService,
+		loginAttemptRepo: loginAttemptRepo,
+		loginProtection:  loginProtection,
+		inputSanitizer:   utils.NewInputSanitizer(1000),
+		errorHandler:     utils.NewErrorHandler(),
+		securityLogger:   utils.NewSecurityLogger(),
+		notifier:         notifier,
 	}
 }
 
 // Login handles admin login
 // @Summary Admin login
-// @Description Authenticate admin user and return JWT token
+// @Description Authenticate admin user and return JWT token. Admins with two-factor authentication enabled get a 202 with an MFA token instead, to be exchanged at /admin/login/mfa. Failed logins are tracked per username and per IP: each one delays the next attempt further, and repeated failures lock the username or IP out. Rejected attempts get a 429 with a Retry-After header.
 // @Tags admin
 // @Accept json
 // @Produce json
 // @Param loginRequest body models.LoginRequest true "Login credentials"
 // @Success 200 {object} models.LoginResponse
+// @Success 202 {object} models.MFAChallengeResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 401 {object} models.ErrorResponse
 // @Failure 429 {object} models.ErrorResponse
@@ -85,21 +99,8 @@ func (h *AdminHandler) Login(c *gin.Context) {
 
 	req.Username = h.inputSanitizer.SanitizeUsername(req.Username)
 
-	clientIP := c.ClientIP()
-
-	failedAttempts, err := h.loginAttemptRepo.GetFailedLoginAttempts(
-		clientIP,
-		time.Now().Add(-h.failedAttemptWindow),
-	)
-	if err != nil {
-		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to check login attempts: %v", err))
-		h.errorHandler.HandleError(c, err, "Failed to process login request", utils.ErrorLevelError)
-		return
-	}
-
-	if failedAttempts >= h.maxFailedAttempts {
-		h.logLoginAttempt(c, false, fmt.Sprintf("IP locked out due to %d failed attempts", failedAttempts))
-		h.errorHandler.HandleRateLimitError(c, fmt.Sprintf("IP address locked out for %v due to too many failed login attempts", h.lockoutDuration))
+	claim, ok := h.claimLoginAttempt(c, req.Username)
+	if !ok {
 		return
 	}
 
@@ -112,12 +113,22 @@ func (h *AdminHandler) Login(c *gin.Context) {
 
 	if admin == nil {
 		h.logLoginAttempt(c, false, "User not found")
+		h.recordLoginFailure(c, req.Username)
 		h.errorHandler.HandleAuthError(c, fmt.Errorf("user not found"), "Username or password is incorrect")
 		return
 	}
 
+	// Disabled accounts get the same response as unknown ones so they can't be probed
+	if !admin.IsActive {
+		h.logLoginAttempt(c, false, "Account disabled")
+		h.recordLoginFailure(c, req.Username)
+		h.errorHandler.HandleAuthError(c, fmt.Errorf("account disabled"), "Username or password is incorrect")
+		return
+	}
+
 	if err := h.authService.VerifyPasswordWithHashVersion(admin.PasswordHash, req.Password, admin.HashVersion, admin.PasswordSalt); err != nil {
 		h.logLoginAttempt(c, false, "Invalid password: "+err.Error())
+		h.recordLoginFailure(c, req.Username)
 		if err.Error() == "legacy password format no longer supported - please reset your password" {
 			h.errorHandler.HandleAuthError(c, err, "Your password format needs to be updated. Please reset your password.")
 		} else {
@@ -126,53 +137,185 @@ func (h *AdminHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokenPair, err := h.authService.GenerateTokenPair(admin.ID, admin.Username)
+	if admin.TOTPEnabledAt.Valid {
+		// The password was right, so nothing is counted yet; the code is claimed as its own attempt
+		h.loginProtection.ReleaseAttempt(claim)
+
+		mfaToken, expiresAt, err := h.authService.GenerateMFAToken(admin.ID, admin.Username)
+		if err != nil {
+			h.logLoginAttempt(c, false, fmt.Sprintf("Failed to issue MFA token: %v", err))
+			h.errorHandler.HandleError(c, err, "Failed to complete login process", utils.ErrorLevelError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, models.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresAt:   expiresAt,
+		})
+		return
+	}
+
+	h.completeLogin(c, admin, "Login successful")
+}
+
+// VerifyMFA handles the second step of login for admins with two-factor authentication
+// @Summary Complete two-factor login
+// @Description Exchange the MFA token from login and a TOTP code or unused recovery code for a session
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param mfaLoginRequest body models.MFALoginRequest true "MFA token and code"
+// @Success 200 {object} models.LoginResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/login/mfa [post]
+func (h *AdminHandler) VerifyMFA(c *gin.Context) {
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	claims, err := h.authService.ValidateMFAToken(req.MFAToken)
 	if err != nil {
-		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to generate token: %v", err))
-		h.errorHandler.HandleError(c, err, "Failed to generate authentication token", utils.ErrorLevelError)
+		h.errorHandler.HandleAuthError(c, err, "Two-factor login has expired, please log in again")
 		return
 	}
 
-	if err := h.adminRepo.UpdateAdminToken(admin.ID, tokenPair.RefreshToken, tokenPair.RefreshExpiresAt); err != nil {
-		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to update token: %v", err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Internal server error",
-			Message: "Failed to complete login process",
+	if _, ok := h.claimLoginAttempt(c, claims.Username); !ok {
+		return
+	}
+
+	admin, err := h.adminRepo.GetAdminByID(claims.UserID)
+	if err != nil {
+		h.logLoginAttempt(c, false, fmt.Sprintf("Database error: %v", err))
+		h.errorHandler.HandleError(c, err, "Failed to process login request", utils.ErrorLevelError)
+		return
+	}
+
+	if admin == nil || !admin.IsActive {
+		h.logLoginAttempt(c, false, "Account missing or disabled during two-factor login")
+		h.errorHandler.HandleAuthError(c, fmt.Errorf("account unavailable"), "Two-factor login has expired, please log in again")
+		return
+	}
+
+	usedRecoveryCode, err := h.mfaService.VerifyCode(admin, req.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMFACode) || errors.Is(err, services.ErrMFANotEnabled) {
+			h.logLoginAttempt(c, false, "Invalid two-factor code")
+			h.recordLoginFailure(c, admin.Username)
+			h.errorHandler.HandleAuthError(c, err, "The two-factor code is invalid or has already been used")
+			return
+		}
+		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to verify two-factor code: %v", err))
+		h.errorHandler.HandleError(c, err, "Failed to process login request", utils.ErrorLevelError)
+		return
+	}
+
+	details := "Login successful"
+	if usedRecoveryCode {
+		details = "Login successful using a recovery code"
+	}
+
+	h.completeLogin(c, admin, details)
+}
+
+// claimLoginAttempt takes a login attempt for the username and client IP, rejecting the request
+// while either is backed off, locked or has another attempt in progress. An empty username
+// claims for the IP alone.
+func (h *AdminHandler) claimLoginAttempt(c *gin.Context, username string) (*services.LoginClaim, bool) {
+	claim, block, err := h.loginProtection.ClaimAttempt(username, c.ClientIP())
+	if err != nil {
+		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to check login lockout: %v", err))
+		h.errorHandler.HandleError(c, err, "Failed to process login request", utils.ErrorLevelError)
+		return nil, false
+	}
+
+	if block == nil {
+		return claim, true
+	}
+
+	retryAfter := block.RetryAfterSeconds(time.Now())
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	if block.Locked {
+		h.logLoginAttempt(c, false, fmt.Sprintf("Login rejected, %s locked out until %s", block.Scope, block.Until.UTC().Format(time.RFC3339)))
+		h.errorHandler.HandleRateLimitError(c, fmt.Sprintf("Too many failed login attempts, login is locked for %d seconds", retryAfter))
+	} else {
+		h.logLoginAttempt(c, false, fmt.Sprintf("Login rejected, %s must wait before retrying", block.Scope))
+		h.errorHandler.HandleRateLimitError(c, fmt.Sprintf("Please wait %d seconds before trying again", retryAfter))
+	}
+	return nil, false
+}
+
+// recordLoginFailure counts a failed login against the username and client IP, and reports any
+// lockout it triggers. An empty username counts against the IP alone.
+func (h *AdminHandler) recordLoginFailure(c *gin.Context, username string) {
+	clientIP := c.ClientIP()
+
+	locked, err := h.loginProtection.RecordFailure(username, clientIP)
+	if err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+	}
+
+	for _, lockout := range locked {
+		duration := lockout.LockedUntil.Time.Sub(lockout.LastFailedAt).Round(time.Second)
+		h.securityLogger.LogSecurityEvent("login_locked_out", map[string]interface{}{
+			"scope":           lockout.Scope,
+			"subject":         lockout.Subject,
+			"failed_attempts": lockout.FailedAttempts,
+			"locked_until":    lockout.LockedUntil.Time.UTC(),
+			"client_ip":       clientIP,
 		})
+		h.notifier.AccountLockedOut(username, clientIP, lockout.FailedAttempts, duration)
+	}
+}
+
+// completeLogin starts a session for an admin who has passed every login step
+func (h *AdminHandler) completeLogin(c *gin.Context, admin *models.Admin, details string) {
+	clientIP := c.ClientIP()
+
+	session, err := h.sessionService.StartSession(admin, clientIP, c.GetHeader("User-Agent"))
+	if err != nil {
+		h.logLoginAttempt(c, false, fmt.Sprintf("Failed to start session: %v", err))
+		h.errorHandler.HandleError(c, err, "Failed to complete login process", utils.ErrorLevelError)
 		return
 	}
 
-	isHttps := os.Getenv("HTTPS_MODE") == "true"
+	setAuthCookies(c, session.Tokens)
 
-	c.SetCookie(
-		"access_token",
-		tokenPair.AccessToken,
-		int(tokenPair.AccessExpiresAt.Sub(time.Now()).Seconds()),
-		"/",
-		"",
-		isHttps,
-		true,
-	)
+	utils.SetAuditActor(c, admin)
+	recordAuditChange(c, "admin_login", auditTargetAdmin, admin.ID, nil, nil)
 
-	c.SetCookie(
-		"refresh_token",
-		tokenPair.RefreshToken,
-		int(tokenPair.RefreshExpiresAt.Sub(time.Now()).Seconds()),
-		"/",
-		"",
-		isHttps,
-		true,
-	)
+	if err := h.loginProtection.RecordSuccess(admin.Username, clientIP); err != nil {
+		log.Printf("Failed to clear login failures: %v", err)
+	}
 
-	h.logLoginAttempt(c, true, "Login successful")
+	// Checked before the success is recorded, since logging happens in the background
+	previousLogins, err := h.loginAttemptRepo.CountSuccessfulLogins(clientIP, admin.ID)
+	if err != nil {
+		log.Printf("Failed to check previous logins: %v", err)
+	} else if previousLogins == 0 {
+		h.notifier.NewLoginLocation(admin.Username, clientIP, c.GetHeader("User-Agent"))
+	}
+
+	h.logSuccessfulLogin(c, admin, details)
 
 	response := models.LoginResponse{
 		Token:     "",
-		ExpiresAt: tokenPair.AccessExpiresAt,
+		ExpiresAt: session.Tokens.AccessExpiresAt,
 		User: models.AdminUser{
-			ID:        admin.ID,
-			Username:  admin.Username,
-			LastLogin: admin.LastLogin,
+			ID:          admin.ID,
+			Username:    admin.Username,
+			Role:        admin.Role,
+			Permissions: auth.PermissionsForRole(admin.Role),
+			LastLogin:   admin.LastLogin,
 		},
 	}
 
@@ -201,7 +344,7 @@ func (h *AdminHandler) Logout(c *gin.Context) {
 
 	adminID := userID.(int)
 
-	if err := h.adminRepo.InvalidateAdminToken(adminID); err != nil {
+	if _, err := h.sessionService.RevokeSession(adminID, c.GetString("sessionID"), services.RevocationReasonLogout); err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Internal server error",
 			Message: "Failed to logout",
@@ -209,9 +352,9 @@ func (h *AdminHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	isHttps := os.Getenv("HTTPS_MODE") == "true"
-	c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
-	c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
+	clearAuthCookies(c)
+
+	recordAuditChange(c, "admin_logout", auditTargetAdmin, adminID, nil, nil)
 
 	c.JSON(http.StatusOK, models.SuccessResponse{
 		Message: "Successfully logged out",
@@ -220,7 +363,7 @@ func (h *AdminHandler) Logout(c *gin.Context) {
 
 // RefreshToken handles token refresh
 // @Summary Refresh access token
-// @Description Refresh access token using refresh token
+// @Description Refresh access token using refresh token. Presenting a refresh token that was already rotated revokes the session.
 // @Tags admin
 // @Produce json
 // @Param adminToken path string true "Admin Token"
@@ -238,54 +381,47 @@ func (h *AdminHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	claims, err := h.authService.ValidateRefreshToken(refreshToken)
+	session, err := h.sessionService.RefreshSession(refreshToken, c.ClientIP(), c.GetHeader("User-Agent"))
 	if err != nil {
-		isHttps := os.Getenv("HTTPS_MODE") == "true"
-		c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
-		c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
-
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "Invalid refresh token",
-		})
+		if errors.Is(err, services.ErrSessionRevoked) {
+			message := "Refresh token is invalid or has been revoked"
+			if errors.Is(err, services.ErrRefreshTokenReused) {
+				message = "Refresh token was already used, the session has been revoked. Please log in again"
+			}
+			clearAuthCookies(c)
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:   "Unauthorized",
+				Message: message,
+			})
+			return
+		}
+		h.errorHandler.HandleError(c, err, "Failed to refresh tokens", utils.ErrorLevelError)
 		return
 	}
 
-	admin, err := h.adminRepo.GetAdminByToken(refreshToken)
-	if err != nil || admin == nil {
-		isHttps := os.Getenv("HTTPS_MODE") == "true"
-		c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
-		c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
-
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "Refresh token has been revoked",
-		})
-		return
+	// Tokens is nil when a concurrent refresh already set the new cookies
+	if session.Tokens != nil {
+		setAuthCookies(c, session.Tokens)
 	}
 
-	tokenPair, err := h.authService.GenerateTokenPair(claims.UserID, claims.Username)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Internal server error",
-			Message: "Failed to generate new tokens",
-		})
-		return
+	if session.Admin != nil {
+		utils.SetAuditActor(c, session.Admin)
+		recordAuditChange(c, "admin_token_refreshed", auditTargetAdmin, session.Admin.ID, nil, nil)
 	}
 
-	if err := h.adminRepo.UpdateAdminToken(claims.UserID, tokenPair.RefreshToken, tokenPair.RefreshExpiresAt); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Internal server error",
-			Message: "Failed to update tokens",
-		})
-		return
-	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Tokens refreshed successfully",
+	})
+}
 
+// setAuthCookies stores a freshly issued token pair in HTTP-only cookies
+func setAuthCookies(c *gin.Context, tokenPair *auth.TokenPair) {
 	isHttps := os.Getenv("HTTPS_MODE") == "true"
+
 	c.SetCookie(
 		"access_token",
 		tokenPair.AccessToken,
-		int(tokenPair.AccessExpiresAt.Sub(time.Now()).Seconds()),
+		int(time.Until(tokenPair.AccessExpiresAt).Seconds()),
 		"/",
 		"",
 		isHttps,
@@ -295,19 +431,30 @@ func (h *AdminHandler) RefreshToken(c *gin.Context) {
 	c.SetCookie(
 		"refresh_token",
 		tokenPair.RefreshToken,
-		int(tokenPair.RefreshExpiresAt.Sub(time.Now()).Seconds()),
+		int(time.Until(tokenPair.RefreshExpiresAt).Seconds()),
 		"/",
 		"",
 		isHttps,
 		true,
 	)
+}
 
-	c.JSON(http.StatusOK, models.SuccessResponse{
-		Message: "Tokens refreshed successfully",
-	})
+func clearAuthCookies(c *gin.Context) {
+	isHttps := os.Getenv("HTTPS_MODE") == "true"
+	c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
 }
 
 func (h *AdminHandler) logLoginAttempt(c *gin.Context, success bool, details string) {
+	h.saveLoginAttempt(c, success, details, nil)
+}
+
+// logSuccessfulLogin records a completed login against the admin, for their login history
+func (h *AdminHandler) logSuccessfulLogin(c *gin.Context, admin *models.Admin, details string) {
+	h.saveLoginAttempt(c, true, details, &admin.ID)
+}
+
+func (h *AdminHandler) saveLoginAttempt(c *gin.Context, success bool, details string, adminID *int) {
 	clientIP := c.ClientIP()
 	userAgent := c.GetHeader("User-Agent")
 
@@ -317,7 +464,7 @@ func (h *AdminHandler) logLoginAttempt(c *gin.Context, success bool, details str
 	}
 
 	go func() {
-		if err := h.loginAttemptRepo.CreateLoginAttempt(clientIP, userAgent, success, detailsPtr); err != nil {
+		if err := h.loginAttemptRepo.CreateLoginAttempt(clientIP, userAgent, success, detailsPtr, adminID); err != nil {
 			fmt.Printf("Failed to log login attempt: %v\n", err)
 		}
 	}()
@@ -0,0 +1,496 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminUserHandler struct {
+	authService    *auth.AuthService
+	adminRepo      *repository.AdminRepository
+	sessionService *services.SessionService
+	apiKeyService  *services.APIKeyService
+	inputSanitizer *utils.InputSanitizer
+	errorHandler   *utils.ErrorHandler
+	securityLogger *utils.SecurityLogger
+}
+
+func NewAdminUserHandler(authService *auth.AuthService, adminRepo *repository.AdminRepository, sessionService *services.SessionService, apiKeyService *services.APIKeyService) *AdminUserHandler {
+	return &AdminUserHandler{
+		authService:    authService,
+		adminRepo:      adminRepo,
+		sessionService: sessionService,
+		apiKeyService:  apiKeyService,
+		inputSanitizer: utils.NewInputSanitizer(1000),
+		errorHandler:   utils.NewErrorHandler(),
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// ListAdmins handles GET requests for admin accounts
+// @Summary List admins
+// @Description Get every admin account, oldest first (requires the users:manage permission)
+// @Tags admin-users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.AdminListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users [get]
+func (h *AdminUserHandler) ListAdmins(c *gin.Context) {
+	admins, err := h.adminRepo.ListAdmins()
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load admins", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AdminListResponse{Admins: admins})
+}
+
+// CreateAdmin handles POST requests to add an admin account
+// @Summary Create admin
+// @Description Create a new admin account (requires the users:manage permission). The role defaults to viewer. When no password is supplied a temporary one is generated and returned once.
+// @Tags admin-users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param createAdminRequest body models.CreateAdminRequest true "New admin"
+// @Success 201 {object} models.CreateAdminResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users [post]
+func (h *AdminUserHandler) CreateAdmin(c *gin.Context) {
+	var req models.CreateAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if err := h.inputSanitizer.ValidateUsername(req.Username); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if req.Role == "" {
+		req.Role = models.AdminRoleViewer
+	}
+	if !req.Role.IsValid() {
+		respondInvalidRole(c)
+		return
+	}
+
+	var password, temporaryPassword string
+	if req.Password != nil {
+		password = *req.Password
+		if err := h.inputSanitizer.ValidatePassword(password); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: err.Error(),
+			})
+			return
+		}
+	} else {
+		generated, err := h.authService.GenerateTemporaryPassword()
+		if err != nil {
+			h.errorHandler.HandleError(c, err, "Failed to create admin", utils.ErrorLevelError)
+			return
+		}
+		password = generated
+		temporaryPassword = generated
+	}
+
+	passwordHash, err := h.authService.HashPassword(password)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to create admin", utils.ErrorLevelError)
+		return
+	}
+
+	actor := currentAdmin(c)
+	admin, err := h.adminRepo.CreateAdminBy(req.Username, passwordHash, req.Role, actor.ID)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to create admin")
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_user_created", admin, map[string]interface{}{
+		"temporary_password": temporaryPassword != "",
+		"role":               admin.Role,
+	})
+
+	c.JSON(http.StatusCreated, models.CreateAdminResponse{
+		Admin:             *admin,
+		TemporaryPassword: temporaryPassword,
+	})
+}
+
+// DisableAdmin handles POST requests to deactivate an admin account
+// @Summary Disable admin
+// @Description Deactivate an admin account and revoke its sessions and API keys (requires the users:manage permission). The last active owner cannot be disabled.
+// @Tags admin-users
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users/{id}/disable [post]
+func (h *AdminUserHandler) DisableAdmin(c *gin.Context) {
+	id, ok := h.parseTargetID(c, "disable")
+	if !ok {
+		return
+	}
+
+	admin, err := h.adminRepo.DisableAdmin(id)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to disable admin")
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeAllSessions(admin.ID, services.RevocationReasonAdminDisabled)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke sessions", utils.ErrorLevelError)
+		return
+	}
+
+	revokedKeys, err := h.apiKeyService.RevokeAllKeys(admin.ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke API keys", utils.ErrorLevelError)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_user_disabled", admin, map[string]interface{}{
+		"revoked_sessions": revoked,
+		"revoked_api_keys": revokedKeys,
+	})
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// EnableAdmin handles POST requests to reactivate an admin account
+// @Summary Enable admin
+// @Description Reactivate a disabled admin account (requires the users:manage permission)
+// @Tags admin-users
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users/{id}/enable [post]
+func (h *AdminUserHandler) EnableAdmin(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	admin, err := h.adminRepo.EnableAdmin(id)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to enable admin")
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_user_enabled", admin, nil)
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// DeleteAdmin handles DELETE requests for an admin account
+// @Summary Delete admin
+// @Description Permanently delete an admin account (requires the users:manage permission). The last active owner cannot be deleted.
+// @Tags admin-users
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users/{id} [delete]
+func (h *AdminUserHandler) DeleteAdmin(c *gin.Context) {
+	id, ok := h.parseTargetID(c, "delete")
+	if !ok {
+		return
+	}
+
+	// Loaded first so the audit entry can name the deleted account
+	admin, err := h.adminRepo.GetAdminByID(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to delete admin", utils.ErrorLevelError)
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	deleted, err := h.adminRepo.DeleteAdmin(id)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to delete admin")
+		return
+	}
+
+	if !deleted {
+		respondAdminNotFound(c)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_user_deleted", admin, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Admin deleted successfully",
+	})
+}
+
+// UpdateRole handles PUT requests to change another admin's role
+// @Summary Change admin role
+// @Description Set an admin's role to owner, editor or viewer (requires the users:manage permission). Admins cannot change their own role, and the last active owner cannot be demoted. A demotion applies to the admin's existing tokens immediately; a promotion applies from their next token refresh.
+// @Tags admin-users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Param updateRoleRequest body models.UpdateRoleRequest true "New role"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users/{id}/role [put]
+func (h *AdminUserHandler) UpdateRole(c *gin.Context) {
+	id, ok := h.parseTargetID(c, "change the role of")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !req.Role.IsValid() {
+		respondInvalidRole(c)
+		return
+	}
+
+	// Loaded first so the audit entry can record the previous role
+	previous, err := h.adminRepo.GetAdminByID(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to update role", utils.ErrorLevelError)
+		return
+	}
+
+	if previous == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	admin, err := h.adminRepo.UpdateAdminRole(id, req.Role)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to update role")
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_role_changed", admin, map[string]interface{}{
+		"previous_role": previous.Role,
+		"role":          admin.Role,
+	})
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// UpdateUsername handles PUT requests to rename the current admin
+// @Summary Change own username
+// @Description Change the username of the authenticated admin
+// @Tags admin-users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param updateUsernameRequest body models.UpdateUsernameRequest true "New username"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/username [put]
+func (h *AdminUserHandler) UpdateUsername(c *gin.Context) {
+	var req models.UpdateUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if err := h.inputSanitizer.ValidateUsername(req.Username); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := currentAdmin(c)
+	previousUsername := actor.Username
+
+	admin, err := h.adminRepo.UpdateAdminUsername(actor.ID, req.Username)
+	if err != nil {
+		h.handleWriteError(c, err, "Failed to update username")
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_username_changed", admin, map[string]interface{}{
+		"previous_username": previousUsername,
+	})
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// parseTargetID reads the admin ID and rejects actions an admin may not take on their own account
+func (h *AdminUserHandler) parseTargetID(c *gin.Context, action string) (int, bool) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return 0, false
+	}
+
+	if id == currentAdmin(c).ID {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid operation",
+			Message: "You cannot " + action + " your own account",
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// auditAdminAction records an account change in the security log and describes it for the
+// persistent audit log, which keeps the details as the change's after state
+func auditAdminAction(c *gin.Context, logger *utils.SecurityLogger, action string, target *models.Admin, details map[string]interface{}) {
+	logAdminSecurityEvent(c, logger, action, target, details)
+
+	change := utils.AuditChange{Action: action}
+	if target != nil {
+		change.TargetType = auditTargetAdmin
+		change.TargetID = strconv.Itoa(target.ID)
+	}
+	if len(details) > 0 {
+		change.After = details
+	}
+	utils.SetAuditChange(c, change)
+}
+
+// logAdminSecurityEvent writes an admin API change to the security log with the target and
+// acting admin, if any, and the request origin
+func logAdminSecurityEvent(c *gin.Context, logger *utils.SecurityLogger, action string, target *models.Admin, details map[string]interface{}) {
+	event := map[string]interface{}{
+		"client_ip":  c.ClientIP(),
+		"user_agent": c.GetHeader("User-Agent"),
+	}
+
+	if target != nil {
+		event["target_id"] = target.ID
+		event["target_username"] = target.Username
+	}
+
+	if value, exists := c.Get("admin"); exists {
+		actor := value.(*models.Admin)
+		event["actor_id"] = actor.ID
+		event["actor_username"] = actor.Username
+	}
+
+	for key, value := range details {
+		event[key] = value
+	}
+
+	logger.LogSecurityEvent(action, event)
+}
+
+func (h *AdminUserHandler) handleWriteError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrDuplicateUsername):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Username already in use",
+			Message: "Another admin already uses this username",
+		})
+	case errors.Is(err, repository.ErrLastActiveOwner):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Last active owner",
+			Message: "At least one active owner account must remain",
+		})
+	default:
+		h.errorHandler.HandleError(c, err, message, utils.ErrorLevelError)
+	}
+}
+
+// currentAdmin returns the admin loaded by AuthMiddleware
+func currentAdmin(c *gin.Context) *models.Admin {
+	return c.MustGet("admin").(*models.Admin)
+}
+
+func respondInvalidRole(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "Invalid role",
+		Message: "Role must be one of owner, editor or viewer",
+	})
+}
+
+func respondAdminNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Admin not found",
+		Message: "No admin exists with the given ID",
+	})
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAPIKeyNameLength matches the api_keys.name column
+const maxAPIKeyNameLength = 100
+
+type APIKeyHandler struct {
+	apiKeyService  *services.APIKeyService
+	errorHandler   *utils.ErrorHandler
+	securityLogger *utils.SecurityLogger
+}
+
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService:  apiKeyService,
+		errorHandler:   utils.NewErrorHandler(),
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// ListAPIKeys handles GET requests for the current admin's API keys
+// @Summary List API keys
+// @Description Get the authenticated admin's unrevoked API keys, newest first. Only the key prefix is returned, never the key itself.
+// @Tags admin-api-keys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.APIKeyListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyService.ListKeys(currentAdmin(c).ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load API keys", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIKeyListResponse{APIKeys: keys})
+}
+
+// CreateAPIKey handles POST requests to mint an API key
+// @Summary Create API key
+// @Description Create a named API key for machine clients, sent as "Authorization: ApiKey <key>". Scopes may be any of content, assets and messages read or write that the admin's role grants. The key is only shown in this response.
+// @Tags admin-api-keys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param createAPIKeyRequest body models.CreateAPIKeyRequest true "Key name, scopes and optional expiry"
+// @Success 201 {object} models.CreateAPIKeyResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > maxAPIKeyNameLength {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "Name must be between 1 and 100 characters",
+		})
+		return
+	}
+
+	if len(req.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "At least one scope is required",
+		})
+		return
+	}
+
+	admin := currentAdmin(c)
+	key, rawKey, err := h.apiKeyService.CreateKey(admin, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAPIKeyScopeInvalid), errors.Is(err, services.ErrAPIKeyExpiryInvalid):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: err.Error(),
+			})
+		default:
+			h.errorHandler.HandleError(c, err, "Failed to create API key", utils.ErrorLevelError)
+		}
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_api_key_created", admin, map[string]interface{}{
+		"api_key_id": key.ID,
+		"name":       key.Name,
+		"scopes":     key.Scopes,
+	})
+
+	c.JSON(http.StatusCreated, models.CreateAPIKeyResponse{
+		APIKey: *key,
+		Key:    rawKey,
+	})
+}
+
+// RevokeAPIKey handles DELETE requests for one of the current admin's API keys
+// @Summary Revoke API key
+// @Description Revoke one of the authenticated admin's API keys. Requests using it are rejected immediately.
+// @Tags admin-api-keys
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	admin := currentAdmin(c)
+	key, err := h.apiKeyService.RevokeKey(id, admin.ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke API key", utils.ErrorLevelError)
+		return
+	}
+
+	if key == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "API key not found",
+			Message: "No active API key exists with the given ID",
+		})
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_api_key_revoked", admin, map[string]interface{}{
+		"api_key_id": key.ID,
+		"name":       key.Name,
+	})
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "API key revoked successfully",
+	})
+}
+
+// RevokeAllAPIKeys handles DELETE requests for every API key of the current admin
+// @Summary Revoke all API keys
+// @Description Revoke every API key of the authenticated admin
+// @Tags admin-api-keys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.RevokeAPIKeysResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/api-keys [delete]
+func (h *APIKeyHandler) RevokeAllAPIKeys(c *gin.Context) {
+	admin := currentAdmin(c)
+	revoked, err := h.apiKeyService.RevokeAllKeys(admin.ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke API keys", utils.ErrorLevelError)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_api_keys_revoked", admin, map[string]interface{}{
+		"revoked_api_keys": revoked,
+	})
+
+	c.JSON(http.StatusOK, models.RevokeAPIKeysResponse{
+		Message: "API keys revoked successfully",
+		Revoked: revoked,
+	})
+}
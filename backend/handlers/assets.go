@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/Wildcard209/portfolio-webapplication/models"
 	"github.com/Wildcard209/portfolio-webapplication/services"
@@ -13,54 +15,79 @@ import (
 
 type AssetHandler struct {
 	assetService *services.AssetService
+	errorHandler *utils.ErrorHandler
 }
 
 func NewAssetHandler(assetService *services.AssetService) *AssetHandler {
 	return &AssetHandler{
 		assetService: assetService,
+		errorHandler: utils.NewErrorHandler(),
 	}
 }
 
-// GetHeroBanner handles GET requests for hero banner image
-// @Summary Get hero banner image
-// @Description Get the current hero banner image
+// GetAsset handles GET requests for an asset slot
+// @Summary Get asset
+// @Description Stream the file currently stored in a named asset slot. Image slots can return a resized variant: pass w for the display width and the narrowest variant at least that wide is served. WebP variants are preferred when format=webp is given or the Accept header includes image/webp. Conditional and Range requests are supported.
 // @Tags assets
-// @Produce image/jpeg,image/png,image/gif,image/webp
-// @Success 200 {file} file "Hero banner image"
+// @Produce image/jpeg,image/png,image/gif,image/webp,image/x-icon,application/pdf
+// @Param slot path string true "Asset slot" example(hero-banner)
+// @Param w query int false "Desired width in pixels"
+// @Param format query string false "Preferred variant format" Enums(jpeg, png, webp)
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param If-Modified-Since header string false "Last-Modified from a previous response"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200 {file} file "Asset file"
+// @Success 206 {file} file "Requested byte range"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 416 {string} string "Range not satisfiable"
 // @Failure 500 {object} models.ErrorResponse
-// @Router /api/assets/hero-banner [get]
-func (h *AssetHandler) GetHeroBanner(c *gin.Context) {
-	data, contentType, err := h.assetService.GetHeroBanner()
+// @Router /assets/{slot} [get]
+func (h *AssetHandler) GetAsset(c *gin.Context) {
+	slot := c.Param("slot")
+
+	request, ok := parseAssetVariantRequest(c)
+	if !ok {
+		return
+	}
+
+	object, err := h.assetService.GetAsset(slot, request)
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Hero banner not found",
-			Message: "No hero banner image has been uploaded yet",
-		})
+		h.handleAssetError(c, err, "Failed to load asset")
 		return
 	}
 
-	c.Header("Content-Type", contentType)
-	c.Header("Cache-Control", "public, max-age=3600") // Cache for 1 hour
-	c.Data(http.StatusOK, contentType, data)
+	// Only image variants are picked by Accept, and only when no explicit format is requested
+	if assetSlot, ok := services.GetAssetSlot(slot); ok && assetSlot.Variants && c.Query("format") == "" {
+		c.Header("Vary", "Accept")
+	}
+	serveAssetObject(c, object)
 }
 
-// UploadHeroBanner handles POST requests to upload hero banner image
-// @Summary Upload hero banner image
-// @Description Upload a new hero banner image (requires authentication)
+// UploadAsset handles POST requests to replace the file in an asset slot
+// @Summary Upload asset
+// @Description Upload a file into a named asset slot, replacing any existing file (requires authentication)
 // @Tags assets
 // @Security BearerAuth
 // @Accept multipart/form-data
 // @Produce json
-// @Param adminToken path string true "Admin Token"
-// @Param file formData file true "Hero banner image file"
+// @Param slot path string true "Asset slot" example(hero-banner)
+// @Param file formData file true "Asset file"
 // @Success 200 {object} models.SuccessResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 413 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
-// @Router /{adminToken}/admin/assets/hero-banner [post]
-func (h *AssetHandler) UploadHeroBanner(c *gin.Context) {
+// @Router /admin/assets/{slot} [post]
+func (h *AssetHandler) UploadAsset(c *gin.Context) {
+	slot, ok := services.GetAssetSlot(c.Param("slot"))
+	if !ok {
+		respondUnknownAssetSlot(c)
+		return
+	}
+
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -71,10 +98,12 @@ func (h *AssetHandler) UploadHeroBanner(c *gin.Context) {
 	}
 	defer file.Close()
 
-	maxFileSize := getMaxFileSize()
+	maxFileSize := slot.MaxSize
+	if globalMax := getMaxFileSize(); globalMax < maxFileSize {
+		maxFileSize = globalMax
+	}
 
-	allowedTypes := []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
-	fileValidator := utils.NewFileValidator(maxFileSize, 255, allowedTypes)
+	fileValidator := utils.NewFileValidator(maxFileSize, 255, slot.AllowedTypes)
 
 	if err := fileValidator.ValidateFile(file, header); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -84,55 +113,131 @@ func (h *AssetHandler) UploadHeroBanner(c *gin.Context) {
 		return
 	}
 
-	sanitizedFilename := utils.SanitizeFilename(header.Filename)
+	header.Filename = utils.SanitizeFilename(header.Filename)
 
-	header.Filename = sanitizedFilename
+	if err := h.assetService.UploadAsset(slot.Name, file, header); err != nil {
+		if errors.Is(err, services.ErrInvalidImage) {
+			respondInvalidImage(c)
+			return
+		}
+		h.errorHandler.HandleError(c, err, "Failed to upload asset", utils.ErrorLevelError)
+		return
+	}
 
-	err = h.assetService.UploadHeroBanner(file, header)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Upload failed",
-			Message: "Failed to upload hero banner image",
-		})
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Asset uploaded successfully",
+	})
+}
+
+// DeleteAsset handles DELETE requests to empty an asset slot
+// @Summary Delete asset
+// @Description Remove the file stored in a named asset slot (requires authentication)
+// @Tags assets
+// @Security BearerAuth
+// @Produce json
+// @Param slot path string true "Asset slot" example(hero-banner)
+// @Success 200 {object} models.SuccessResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/assets/{slot} [delete]
+func (h *AssetHandler) DeleteAsset(c *gin.Context) {
+	if err := h.assetService.DeleteAsset(c.Param("slot")); err != nil {
+		h.handleAssetError(c, err, "Failed to delete asset")
 		return
 	}
 
 	c.JSON(http.StatusOK, models.SuccessResponse{
-		Message: "Hero banner uploaded successfully",
+		Message: "Asset deleted successfully",
 	})
 }
 
 // GetAssetInfo returns information about available assets
 // @Summary Get asset information
-// @Description Get information about available assets
+// @Description Get availability and metadata for every asset slot
 // @Tags assets
 // @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Router /api/assets/info [get]
+// @Success 200 {object} models.AssetInfoResponse
+// @Router /assets/info [get]
 func (h *AssetHandler) GetAssetInfo(c *gin.Context) {
-	hasHeroBanner := h.assetService.HasHeroBanner()
-
-	c.JSON(http.StatusOK, gin.H{
-		"hero_banner_available": hasHeroBanner,
-		"hero_banner_url":       "/api/assets/hero-banner",
+	c.JSON(http.StatusOK, models.AssetInfoResponse{
+		Assets: h.assetService.GetAssetInfo(),
 	})
 }
 
-func isValidImageType(contentType string) bool {
-	validTypes := []string{
-		"image/jpeg",
-		"image/jpg",
-		"image/png",
-		"image/gif",
-		"image/webp",
+func (h *AssetHandler) handleAssetError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownAssetSlot):
+		respondUnknownAssetSlot(c)
+	case errors.Is(err, services.ErrAssetNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Asset not found",
+			Message: "No file has been uploaded to this asset slot yet",
+		})
+	default:
+		h.errorHandler.HandleError(c, err, message, utils.ErrorLevelError)
+	}
+}
+
+// serveAssetObject streams a stored file to the client. http.ServeContent answers
+// If-None-Match/If-Modified-Since with 304 and Range requests with 206 by seeking in
+// the object, so only the requested bytes are fetched from storage.
+func serveAssetObject(c *gin.Context, object *services.AssetObject) {
+	defer object.Close()
+
+	c.Header("Content-Type", object.ContentType)
+	c.Header("Cache-Control", object.CachePolicy.CacheControl())
+	if object.ETag != "" {
+		c.Header("ETag", strconv.Quote(object.ETag))
 	}
 
-	for _, validType := range validTypes {
-		if contentType == validType {
-			return true
+	http.ServeContent(c.Writer, c.Request, "", object.LastModified, object)
+}
+
+func parseAssetVariantRequest(c *gin.Context) (services.AssetVariantRequest, bool) {
+	var request services.AssetVariantRequest
+
+	if widthStr := c.Query("w"); widthStr != "" {
+		width, err := strconv.Atoi(widthStr)
+		if err != nil || width <= 0 || width > 10000 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "w must be a positive integer no greater than 10000",
+			})
+			return request, false
 		}
+		request.Width = width
+	}
+
+	switch c.Query("format") {
+	case "":
+		request.PreferWebP = strings.Contains(c.GetHeader("Accept"), "image/webp")
+	case utils.ImageFormatWebP:
+		request.PreferWebP = true
+	case utils.ImageFormatJPEG, utils.ImageFormatPNG:
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "format must be one of jpeg, png, webp",
+		})
+		return request, false
 	}
-	return false
+
+	return request, true
+}
+
+func respondInvalidImage(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "File validation failed",
+		Message: "The image file is corrupt or uses an unsupported structure",
+	})
+}
+
+func respondUnknownAssetSlot(c *gin.Context) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Asset slot not found",
+		Message: "No asset slot exists with the given name",
+	})
 }
 
 func getMaxFileSize() int64 {
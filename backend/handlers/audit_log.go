@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Target types for audit log entries, matching the route names the audit middleware falls back to
+const (
+	auditTargetAdmin   = "users"
+	auditTargetProject = "projects"
+	auditTargetPost    = "blog"
+	auditTargetMedia   = "media"
+	auditTargetIPBlock = "ip-blocks"
+)
+
+type AuditLogHandler struct {
+	auditService *services.AuditService
+	errorHandler *utils.ErrorHandler
+}
+
+func NewAuditLogHandler(auditService *services.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditService: auditService,
+		errorHandler: utils.NewErrorHandler(),
+	}
+}
+
+// ListAuditLog handles GET requests for the audit log of admin changes
+// @Summary List audit log
+// @Description Get recorded changes made through the admin API, newest first. Before and after hold only the fields each change modified. (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Param actor_id query int false "Filter by the ID of the admin who made the change"
+// @Param action query string false "Filter by action, e.g. project_updated"
+// @Param target_type query string false "Filter by target type, e.g. projects"
+// @Param target_id query string false "Filter by target ID"
+// @Param ip query string false "Filter by client IP address or CIDR range"
+// @Param from query string false "Only changes at or after this time (RFC 3339)"
+// @Param to query string false "Only changes before this time (RFC 3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Entries per page" default(10)
+// @Success 200 {object} models.AuditLogListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/audit-log [get]
+func (h *AuditLogHandler) ListAuditLog(c *gin.Context) {
+	var filter models.AuditLogFilter
+
+	if value := c.Query("actor_id"); value != "" {
+		actorID, err := strconv.Atoi(value)
+		if err != nil || actorID < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "actor_id must be a positive integer",
+			})
+			return
+		}
+		filter.ActorID = &actorID
+	}
+
+	filter.Action = optionalQuery(c, "action")
+	filter.TargetType = optionalQuery(c, "target_type")
+	filter.TargetID = optionalQuery(c, "target_id")
+
+	var ok bool
+	if filter.IPAddress, ok = parseIPFilter(c); !ok {
+		return
+	}
+
+	if filter.From, filter.To, ok = parseTimeRange(c); !ok {
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+
+	entries, total, err := h.auditService.ListEntries(filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load audit log", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuditLogListResponse{
+		Entries:    entries,
+		Pagination: models.NewPagination(page, pageSize, total),
+	})
+}
+
+// recordAuditChange describes the change a request made for the persistent audit log. before is
+// nil for creations and after is nil for deletions.
+func recordAuditChange(c *gin.Context, action, targetType string, targetID int, before, after interface{}) {
+	utils.SetAuditChange(c, utils.AuditChange{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   strconv.Itoa(targetID),
+		Before:     before,
+		After:      after,
+	})
+}
+
+func optionalQuery(c *gin.Context, name string) *string {
+	value := c.Query(name)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// parseIPFilter reads the optional ip query parameter, an IP address or CIDR range. It responds
+// with a 400 and returns false when the value is neither.
+func parseIPFilter(c *gin.Context) (*string, bool) {
+	ip := c.Query("ip")
+	if ip == "" {
+		return nil, true
+	}
+
+	if net.ParseIP(ip) == nil {
+		if _, _, err := net.ParseCIDR(ip); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "ip must be an IP address or CIDR range",
+			})
+			return nil, false
+		}
+	}
+
+	return &ip, true
+}
@@ -0,0 +1,521 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const maxPostContentLength = 200000
+
+type BlogHandler struct {
+	blogService    *services.BlogService
+	inputSanitizer *utils.InputSanitizer
+	errorHandler   *utils.ErrorHandler
+}
+
+func NewBlogHandler(blogService *services.BlogService) *BlogHandler {
+	return &BlogHandler{
+		blogService:    blogService,
+		inputSanitizer: utils.NewInputSanitizer(1000),
+		errorHandler:   utils.NewErrorHandler(),
+	}
+}
+
+// ListPublishedPosts handles GET requests for the public blog listing
+// @Summary List published blog posts
+// @Description Get published blog posts, newest first
+// @Tags blog
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Posts per page" default(10)
+// @Success 200 {object} models.BlogPostListResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /blog [get]
+func (h *BlogHandler) ListPublishedPosts(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	posts, pagination, err := h.blogService.ListPublishedPosts(page, pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load blog posts", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BlogPostListResponse{
+		Posts:      posts,
+		Pagination: pagination,
+	})
+}
+
+// GetPublishedPost handles GET requests for a single published post
+// @Summary Get blog post by slug
+// @Description Get a single published blog post by its slug
+// @Tags blog
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /blog/{slug} [get]
+func (h *BlogHandler) GetPublishedPost(c *gin.Context) {
+	slug := c.Param("slug")
+	if err := h.inputSanitizer.ValidateSlug(slug); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	post, err := h.blogService.GetPublishedPost(slug)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to load blog post")
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// ListPosts handles GET requests for every post including drafts
+// @Summary List all blog posts
+// @Description Get all blog posts including drafts and scheduled posts (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Filter by status" Enums(draft, scheduled, published)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Posts per page" default(10)
+// @Success 200 {object} models.BlogPostListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog [get]
+func (h *BlogHandler) ListPosts(c *gin.Context) {
+	status := models.BlogPostStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "status must be one of draft, scheduled, published",
+		})
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+
+	posts, pagination, err := h.blogService.ListPosts(status, page, pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load blog posts", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BlogPostListResponse{
+		Posts:      posts,
+		Pagination: pagination,
+	})
+}
+
+// GetPost handles GET requests for a single post by ID
+// @Summary Get blog post by ID
+// @Description Get any blog post by ID regardless of status (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id} [get]
+func (h *BlogHandler) GetPost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	post, err := h.blogService.GetPost(id)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to load blog post")
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// CreatePost handles POST requests to create a draft post
+// @Summary Create blog post draft
+// @Description Create a new blog post as a draft from Markdown content (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param post body models.BlogPostRequest true "Post details"
+// @Success 201 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog [post]
+func (h *BlogHandler) CreatePost(c *gin.Context) {
+	post, ok := h.bindPostRequest(c)
+	if !ok {
+		return
+	}
+
+	if userID, exists := c.Get("userID"); exists {
+		authorID := userID.(int)
+		post.AuthorID = &authorID
+	}
+
+	created, err := h.blogService.CreateDraft(post)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to save blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_created", auditTargetPost, created.ID, nil, created)
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdatePost handles PUT requests to edit a post
+// @Summary Update blog post
+// @Description Edit the content of an existing blog post without changing its status (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param post body models.BlogPostRequest true "Post details"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id} [put]
+func (h *BlogHandler) UpdatePost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	post, ok := h.bindPostRequest(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadPost(c, id, "Failed to save blog post")
+	if !ok {
+		return
+	}
+
+	updated, err := h.blogService.UpdatePost(id, post)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to save blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_updated", auditTargetPost, id, previous, updated)
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// PreviewPost handles POST requests to render Markdown without saving it
+// @Summary Preview blog post content
+// @Description Render Markdown into sanitized HTML and a table of contents without saving (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param preview body models.PreviewPostRequest true "Markdown content"
+// @Success 200 {object} models.PreviewPostResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/preview [post]
+func (h *BlogHandler) PreviewPost(c *gin.Context) {
+	var req models.PreviewPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if len(req.Content) > maxPostContentLength {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: utils.NewValidationError("content", "must not exceed %d characters", maxPostContentLength).Error(),
+		})
+		return
+	}
+
+	rendered, err := h.blogService.PreviewContent(req.Content)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to render preview", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PreviewPostResponse{
+		ContentHTML: rendered.HTML,
+		TOC:         rendered.TableOfContents,
+	})
+}
+
+// SchedulePost handles POST requests to schedule a post for publishing
+// @Summary Schedule blog post
+// @Description Schedule a blog post to become public at a future time (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param schedule body models.SchedulePostRequest true "Publish time"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id}/schedule [post]
+func (h *BlogHandler) SchedulePost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SchedulePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	previous, ok := h.loadPost(c, id, "Failed to schedule blog post")
+	if !ok {
+		return
+	}
+
+	post, err := h.blogService.SchedulePost(id, req.PublishAt)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to schedule blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_scheduled", auditTargetPost, id, previous, post)
+
+	c.JSON(http.StatusOK, post)
+}
+
+// PublishPost handles POST requests to publish a post immediately
+// @Summary Publish blog post
+// @Description Publish a blog post immediately (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id}/publish [post]
+func (h *BlogHandler) PublishPost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadPost(c, id, "Failed to publish blog post")
+	if !ok {
+		return
+	}
+
+	post, err := h.blogService.PublishPost(id)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to publish blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_published", auditTargetPost, id, previous, post)
+
+	c.JSON(http.StatusOK, post)
+}
+
+// UnpublishPost handles POST requests to move a post back to draft
+// @Summary Unpublish blog post
+// @Description Hide a published or scheduled blog post by returning it to draft (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.BlogPost
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id}/unpublish [post]
+func (h *BlogHandler) UnpublishPost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadPost(c, id, "Failed to unpublish blog post")
+	if !ok {
+		return
+	}
+
+	post, err := h.blogService.UnpublishPost(id)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to unpublish blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_unpublished", auditTargetPost, id, previous, post)
+
+	c.JSON(http.StatusOK, post)
+}
+
+// DeletePost handles DELETE requests to remove a post
+// @Summary Delete blog post
+// @Description Permanently delete a blog post (requires authentication)
+// @Tags blog
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/blog/{id} [delete]
+func (h *BlogHandler) DeletePost(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadPost(c, id, "Failed to delete blog post")
+	if !ok {
+		return
+	}
+
+	if err := h.blogService.DeletePost(id); err != nil {
+		h.handleServiceError(c, err, "Failed to delete blog post")
+		return
+	}
+
+	recordAuditChange(c, "blog_post_deleted", auditTargetPost, id, previous, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Blog post deleted successfully",
+	})
+}
+
+// loadPost fetches a post before it is changed, so the audit log can record its previous state
+func (h *BlogHandler) loadPost(c *gin.Context, id int, message string) (*models.BlogPost, bool) {
+	post, err := h.blogService.GetPost(id)
+	if err != nil {
+		h.handleServiceError(c, err, message)
+		return nil, false
+	}
+
+	return post, true
+}
+
+func (h *BlogHandler) bindPostRequest(c *gin.Context) (*models.BlogPost, bool) {
+	var req models.BlogPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	if req.Slug == "" {
+		req.Slug = h.inputSanitizer.SanitizeSlug(req.Title)
+	}
+
+	if err := h.validatePostRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return &models.BlogPost{
+		Slug:          req.Slug,
+		Title:         strings.TrimSpace(req.Title),
+		Excerpt:       optionalString(req.Excerpt),
+		Content:       req.Content,
+		CoverImageURL: optionalString(req.CoverImageURL),
+	}, true
+}
+
+func (h *BlogHandler) validatePostRequest(req *models.BlogPostRequest) error {
+	if err := h.inputSanitizer.ValidateSlug(req.Slug); err != nil {
+		return err
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Title, "title", 1, 200); err != nil {
+		return err
+	}
+
+	// Content may legitimately contain code samples, so only its length is checked here
+	if strings.TrimSpace(req.Content) == "" {
+		return utils.NewValidationError("content", "is required")
+	}
+
+	if len(req.Content) > maxPostContentLength {
+		return utils.NewValidationError("content", "must not exceed %d characters", maxPostContentLength)
+	}
+
+	if req.Excerpt != nil {
+		if err := h.inputSanitizer.ValidateString(*req.Excerpt, "excerpt", 0, 500); err != nil {
+			return err
+		}
+	}
+
+	if req.CoverImageURL != nil && *req.CoverImageURL != "" {
+		if err := h.inputSanitizer.ValidateURL(*req.CoverImageURL, "cover_image_url"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *BlogHandler) handleServiceError(c *gin.Context, err error, userMessage string) {
+	switch {
+	case errors.Is(err, services.ErrPostNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Blog post not found",
+			Message: "No blog post matches the request",
+		})
+	case errors.Is(err, services.ErrInvalidSchedule):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+	case errors.Is(err, repository.ErrDuplicateSlug):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Slug already in use",
+			Message: "Another blog post already uses this slug",
+		})
+	default:
+		h.errorHandler.HandleError(c, err, userMessage, utils.ErrorLevelError)
+	}
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/notify"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ContactHandler struct {
+	contactRepo       *repository.ContactRepository
+	inputSanitizer    *utils.InputSanitizer
+	errorHandler      *utils.ErrorHandler
+	securityLogger    *utils.SecurityLogger
+	notifier          *notify.Notifier
+	formTokens        *utils.FormTokenSigner
+	minSubmitDuration time.Duration
+	maxSubmitDuration time.Duration
+}
+
+func NewContactHandler(contactRepo *repository.ContactRepository, notifier *notify.Notifier, formTokens *utils.FormTokenSigner) *ContactHandler {
+	return &ContactHandler{
+		contactRepo:       contactRepo,
+		inputSanitizer:    utils.NewInputSanitizer(5000),
+		errorHandler:      utils.NewErrorHandler(),
+		securityLogger:    utils.NewSecurityLogger(),
+		notifier:          notifier,
+		formTokens:        formTokens,
+		minSubmitDuration: 3 * time.Second,
+		maxSubmitDuration: 24 * time.Hour,
+	}
+}
+
+// GetFormToken handles GET requests for a contact form token
+// @Summary Get contact form token
+// @Description Get a signed token recording when the contact form was rendered. It must be sent with the submission, no sooner than a few seconds and no later than a day after it was issued.
+// @Tags contact
+// @Produce json
+// @Success 200 {object} models.ContactFormTokenResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Router /contact/token [get]
+func (h *ContactHandler) GetFormToken(c *gin.Context) {
+	c.JSON(http.StatusOK, models.ContactFormTokenResponse{
+		FormToken: h.formTokens.Issue(time.Now()),
+	})
+}
+
+// SubmitContactMessage handles contact form submissions
+// @Summary Submit contact form
+// @Description Send a message to the site owner. Submissions that trip the spam checks are accepted but discarded.
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Param contactRequest body models.ContactRequest true "Contact form"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /contact [post]
+func (h *ContactHandler) SubmitContactMessage(c *gin.Context) {
+	var req models.ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Bots are told the message was sent so they have no signal to adapt to
+	if reason := h.detectSpam(&req); reason != "" {
+		h.securityLogger.LogSecurityEvent("contact_spam_rejected", map[string]interface{}{
+			"client_ip":  c.ClientIP(),
+			"user_agent": c.GetHeader("User-Agent"),
+			"reason":     reason,
+		})
+		c.JSON(http.StatusOK, models.SuccessResponse{
+			Message: "Message sent successfully",
+		})
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+	req.Message = strings.TrimSpace(req.Message)
+
+	if err := h.validateContactRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userAgent := c.GetHeader("User-Agent")
+	message := &models.ContactMessage{
+		Name:      req.Name,
+		Email:     req.Email,
+		Subject:   optionalString(req.Subject),
+		Message:   req.Message,
+		IPAddress: c.ClientIP(),
+		UserAgent: optionalString(&userAgent),
+	}
+
+	created, err := h.contactRepo.CreateMessage(message)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to send message", utils.ErrorLevelError)
+		return
+	}
+
+	h.notifier.ContactMessageReceived(created)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Message sent successfully",
+	})
+}
+
+// ListContactMessages handles GET requests for received messages
+// @Summary List contact messages
+// @Description Get received contact messages, newest first (requires authentication)
+// @Tags contact
+// @Security BearerAuth
+// @Produce json
+// @Param handled query bool false "Filter by handled state"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Messages per page" default(10)
+// @Success 200 {object} models.ContactMessageListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/contact [get]
+func (h *ContactHandler) ListContactMessages(c *gin.Context) {
+	var handled *bool
+	switch c.Query("handled") {
+	case "":
+	case "true":
+		value := true
+		handled = &value
+	case "false":
+		value := false
+		handled = &value
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "handled must be true or false",
+		})
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+
+	messages, total, err := h.contactRepo.ListMessages(handled, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load messages", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ContactMessageListResponse{
+		Messages:   messages,
+		Pagination: models.NewPagination(page, pageSize, total),
+	})
+}
+
+// GetContactMessage handles GET requests for a single message
+// @Summary Get contact message
+// @Description Get a single contact message by ID (requires authentication)
+// @Tags contact
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Message ID"
+// @Success 200 {object} models.ContactMessage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/contact/{id} [get]
+func (h *ContactHandler) GetContactMessage(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	message, err := h.contactRepo.GetMessageByID(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load message", utils.ErrorLevelError)
+		return
+	}
+
+	if message == nil {
+		respondContactMessageNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// MarkContactMessageHandled handles POST requests to mark a message as handled
+// @Summary Mark contact message as handled
+// @Description Flag a contact message as dealt with (requires authentication)
+// @Tags contact
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Message ID"
+// @Success 200 {object} models.ContactMessage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/contact/{id}/handled [post]
+func (h *ContactHandler) MarkContactMessageHandled(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	message, err := h.contactRepo.MarkMessageHandled(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to update message", utils.ErrorLevelError)
+		return
+	}
+
+	if message == nil {
+		respondContactMessageNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// DeleteContactMessage handles DELETE requests for a message
+// @Summary Delete contact message
+// @Description Permanently delete a contact message (requires authentication)
+// @Tags contact
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Message ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/contact/{id} [delete]
+func (h *ContactHandler) DeleteContactMessage(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.contactRepo.DeleteMessage(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to delete message", utils.ErrorLevelError)
+		return
+	}
+
+	if !deleted {
+		respondContactMessageNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Message deleted successfully",
+	})
+}
+
+// detectSpam returns a non-empty reason when the submission looks automated
+func (h *ContactHandler) detectSpam(req *models.ContactRequest) string {
+	if strings.TrimSpace(req.Website) != "" {
+		return "honeypot field filled"
+	}
+
+	issuedAt, err := h.formTokens.IssuedAt(req.FormToken)
+	if err != nil {
+		return "invalid form token"
+	}
+
+	elapsed := time.Since(issuedAt)
+	if elapsed < h.minSubmitDuration {
+		return "submitted too quickly"
+	}
+
+	if elapsed > h.maxSubmitDuration {
+		return "form timestamp expired"
+	}
+
+	return ""
+}
+
+func (h *ContactHandler) validateContactRequest(req *models.ContactRequest) error {
+	if err := h.inputSanitizer.ValidateString(req.Name, "name", 1, 100); err != nil {
+		return err
+	}
+
+	if err := h.inputSanitizer.ValidateEmail(req.Email); err != nil {
+		return err
+	}
+
+	if req.Subject != nil {
+		if err := h.inputSanitizer.ValidateString(*req.Subject, "subject", 0, 200); err != nil {
+			return err
+		}
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Message, "message", 10, 5000); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func respondContactMessageNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Message not found",
+		Message: "No contact message exists with the given ID",
+	})
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type IPBlockHandler struct {
+	ipAccess       *services.IPAccessService
+	inputSanitizer *utils.InputSanitizer
+	errorHandler   *utils.ErrorHandler
+	securityLogger *utils.SecurityLogger
+}
+
+func NewIPBlockHandler(ipAccess *services.IPAccessService) *IPBlockHandler {
+	return &IPBlockHandler{
+		ipAccess:       ipAccess,
+		inputSanitizer: utils.NewInputSanitizer(1000),
+		errorHandler:   utils.NewErrorHandler(),
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// ListBlocks handles GET requests for the temporary IP blocks in force
+// @Summary List IP blocks
+// @Description Get the temporary IP blocks that have not expired, newest first. Blocks set in configuration are not included. (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.IPBlockListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/ip-blocks [get]
+func (h *IPBlockHandler) ListBlocks(c *gin.Context) {
+	blocks, err := h.ipAccess.ListBlocks()
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load IP blocks", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.IPBlockListResponse{Blocks: blocks})
+}
+
+// CreateBlock handles POST requests to block an IP address or range until a given time
+// @Summary Block IP
+// @Description Temporarily block an IP address or CIDR range. Scope admin blocks only the admin API, scope all blocks every route. A block covering the caller's own address is refused. (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param createIPBlockRequest body models.CreateIPBlockRequest true "Address or range, scope, reason and expiry"
+// @Success 201 {object} models.IPBlock
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/ip-blocks [post]
+func (h *IPBlockHandler) CreateBlock(c *gin.Context) {
+	var req models.CreateIPBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	network, err := utils.ParseNetwork(req.Network)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "network must be an IP address or CIDR range",
+		})
+		return
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Reason, "reason", 0, 500); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Every scope covers the admin API, so this would lock the caller out
+	if clientIP := net.ParseIP(c.ClientIP()); clientIP != nil && network.Contains(clientIP) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "The block would include your own IP address",
+		})
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	admin := currentAdmin(c)
+
+	block, err := h.ipAccess.Block(network.String(), req.Scope, optionalString(&reason), req.ExpiresAt, admin.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidIPBlockScope):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "Scope must be admin or all",
+			})
+		case errors.Is(err, services.ErrInvalidIPBlockExpiry):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "expires_at must be in the future",
+			})
+		default:
+			h.errorHandler.HandleError(c, err, "Failed to block IP", utils.ErrorLevelError)
+		}
+		return
+	}
+
+	logAdminSecurityEvent(c, h.securityLogger, "admin_ip_blocked", nil, map[string]interface{}{
+		"block_id":   block.ID,
+		"network":    block.Network,
+		"scope":      block.Scope,
+		"expires_at": block.ExpiresAt,
+	})
+	recordAuditChange(c, "admin_ip_blocked", auditTargetIPBlock, block.ID, nil, block)
+
+	c.JSON(http.StatusCreated, block)
+}
+
+// DeleteBlock handles DELETE requests to lift a temporary IP block
+// @Summary Unblock IP
+// @Description Lift a temporary IP block before it expires (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Block ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/ip-blocks/{id} [delete]
+func (h *IPBlockHandler) DeleteBlock(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.ipAccess.Unblock(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to unblock IP", utils.ErrorLevelError)
+		return
+	}
+
+	if !deleted {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "IP block not found",
+			Message: "No IP block exists with the given ID",
+		})
+		return
+	}
+
+	logAdminSecurityEvent(c, h.securityLogger, "admin_ip_unblocked", nil, map[string]interface{}{
+		"block_id": id,
+	})
+	recordAuditChange(c, "admin_ip_unblocked", auditTargetIPBlock, id, nil, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "IP unblocked successfully",
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// jwksCacheControl lets verifiers cache the key set for five minutes. When rotating, list the new
+// key in JWT_VERIFICATION_KEY_FILES for at least that long before making it the signing key.
+const jwksCacheControl = "public, max-age=300"
+
+type JWKSHandler struct {
+	authService *auth.AuthService
+}
+
+func NewJWKSHandler(authService *auth.AuthService) *JWKSHandler {
+	return &JWKSHandler{authService: authService}
+}
+
+// GetJWKS serves the public keys tokens are signed with, so other services can verify admin
+// tokens without calling the API. It is mounted at /.well-known/jwks.json, outside /api.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksCacheControl)
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
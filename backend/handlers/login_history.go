@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultStatsWindow is the period login stats cover when no range is given
+	defaultStatsWindow  = 24 * time.Hour
+	defaultStatsTop     = 10
+	defaultRecentLogins = 10
+	maxRecentLogins     = 50
+)
+
+type LoginHistoryHandler struct {
+	loginAttemptRepo *repository.LoginAttemptRepository
+	errorHandler     *utils.ErrorHandler
+}
+
+func NewLoginHistoryHandler(loginAttemptRepo *repository.LoginAttemptRepository) *LoginHistoryHandler {
+	return &LoginHistoryHandler{
+		loginAttemptRepo: loginAttemptRepo,
+		errorHandler:     utils.NewErrorHandler(),
+	}
+}
+
+// ListLoginAttempts handles GET requests for recorded login attempts
+// @Summary List login attempts
+// @Description Get recorded login attempts, newest first (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Param success query bool false "Filter by outcome"
+// @Param ip query string false "Filter by client IP address or CIDR range"
+// @Param from query string false "Only attempts at or after this time (RFC 3339)"
+// @Param to query string false "Only attempts before this time (RFC 3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Attempts per page" default(10)
+// @Success 200 {object} models.LoginAttemptListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/login-attempts [get]
+func (h *LoginHistoryHandler) ListLoginAttempts(c *gin.Context) {
+	var filter models.LoginAttemptFilter
+
+	switch c.Query("success") {
+	case "":
+	case "true":
+		value := true
+		filter.Success = &value
+	case "false":
+		value := false
+		filter.Success = &value
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "success must be true or false",
+		})
+		return
+	}
+
+	var ok bool
+	if filter.IPAddress, ok = parseIPFilter(c); !ok {
+		return
+	}
+
+	if filter.From, filter.To, ok = parseTimeRange(c); !ok {
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+
+	attempts, total, err := h.loginAttemptRepo.ListLoginAttempts(filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load login attempts", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LoginAttemptListResponse{
+		Attempts:   attempts,
+		Pagination: models.NewPagination(page, pageSize, total),
+	})
+}
+
+// GetLoginStats handles GET requests for aggregated login attempt statistics
+// @Summary Get login statistics
+// @Description Get failed logins per hour, the IPs with the most failures and the user agents seen over a period, the last 24 hours by default (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Param from query string false "Start of the period (RFC 3339)"
+// @Param to query string false "End of the period (RFC 3339), defaults to now"
+// @Param top query int false "Number of IPs and user agents to return" default(10)
+// @Success 200 {object} models.LoginAttemptStats
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/login-attempts/stats [get]
+func (h *LoginHistoryHandler) GetLoginStats(c *gin.Context) {
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+
+	end := time.Now().UTC()
+	if to != nil {
+		end = *to
+	}
+
+	start := end.Add(-defaultStatsWindow)
+	if from != nil {
+		start = *from
+	}
+
+	if !start.Before(end) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "from must be before to",
+		})
+		return
+	}
+
+	top, ok := parseLimitQuery(c, "top", defaultStatsTop, maxPageSize)
+	if !ok {
+		return
+	}
+
+	stats, err := h.loginAttemptRepo.GetLoginAttemptStats(start, end, top)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load login statistics", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetRecentLogins handles GET requests for the current admin's latest successful logins
+// @Summary Get recent logins
+// @Description Get the authenticated admin's most recent successful logins, newest first, so unexpected sign-ins can be spotted
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Number of logins to return" default(10)
+// @Success 200 {object} models.RecentLoginsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/logins [get]
+func (h *LoginHistoryHandler) GetRecentLogins(c *gin.Context) {
+	limit, ok := parseLimitQuery(c, "limit", defaultRecentLogins, maxRecentLogins)
+	if !ok {
+		return
+	}
+
+	logins, err := h.loginAttemptRepo.ListSuccessfulLoginsByAdmin(currentAdmin(c).ID, limit)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load recent logins", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RecentLoginsResponse{Logins: logins})
+}
+
+// parseTimeRange reads the optional from and to query parameters as RFC 3339 times. It responds
+// with a 400 and returns false when either is malformed or the range is empty.
+func parseTimeRange(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var times [2]*time.Time
+	for i, name := range []string{"from", "to"} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: name + " must be an RFC 3339 timestamp",
+			})
+			return nil, nil, false
+		}
+		parsed = parsed.UTC()
+		times[i] = &parsed
+	}
+
+	if times[0] != nil && times[1] != nil && !times[0].Before(*times[1]) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "from must be before to",
+		})
+		return nil, nil, false
+	}
+
+	return times[0], times[1], true
+}
+
+// parseLimitQuery reads a positive count from the query string, capped at max. It responds with
+// a 400 and returns false when the value is not a positive integer.
+func parseLimitQuery(c *gin.Context, name string, defaultValue, max int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue, true
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: name + " must be a positive integer",
+		})
+		return 0, false
+	}
+
+	if limit > max {
+		limit = max
+	}
+
+	return limit, true
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type LoginLockoutHandler struct {
+	loginProtection *services.LoginProtectionService
+	errorHandler    *utils.ErrorHandler
+	securityLogger  *utils.SecurityLogger
+}
+
+func NewLoginLockoutHandler(loginProtection *services.LoginProtectionService) *LoginLockoutHandler {
+	return &LoginLockoutHandler{
+		loginProtection: loginProtection,
+		errorHandler:    utils.NewErrorHandler(),
+		securityLogger:  utils.NewSecurityLogger(),
+	}
+}
+
+// ListLockouts handles GET requests for usernames and IPs currently held back from logging in
+// @Summary List login lockouts
+// @Description Get every username and client IP that is currently locked out or backed off after failed logins (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.LoginLockoutListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/lockouts [get]
+func (h *LoginLockoutHandler) ListLockouts(c *gin.Context) {
+	lockouts, err := h.loginProtection.ListActive()
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load login lockouts", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LoginLockoutListResponse{Lockouts: lockouts})
+}
+
+// Unlock handles POST requests to lift a login lockout
+// @Summary Unlock login
+// @Description Lift the lockout on a username or client IP and forget its failed logins (requires the security:manage permission)
+// @Tags admin-security
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param unlockLoginRequest body models.UnlockLoginRequest true "Scope (username or ip) and the username or IP to unlock"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/lockouts/unlock [post]
+func (h *LoginLockoutHandler) Unlock(c *gin.Context) {
+	var req models.UnlockLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	unlocked, err := h.loginProtection.Unlock(req.Scope, strings.TrimSpace(req.Subject))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLockoutScope) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "Scope must be username or ip",
+			})
+			return
+		}
+		h.errorHandler.HandleError(c, err, "Failed to unlock login", utils.ErrorLevelError)
+		return
+	}
+
+	if !unlocked {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Lockout not found",
+			Message: "No failed logins are recorded for the given " + req.Scope,
+		})
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_login_unlocked", nil, map[string]interface{}{
+		"scope":   req.Scope,
+		"subject": req.Subject,
+	})
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Login unlocked successfully",
+	})
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService   *services.MediaService
+	inputSanitizer *utils.InputSanitizer
+	errorHandler   *utils.ErrorHandler
+}
+
+func NewMediaHandler(mediaService *services.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService:   mediaService,
+		inputSanitizer: utils.NewInputSanitizer(1000),
+		errorHandler:   utils.NewErrorHandler(),
+	}
+}
+
+// ServeMedia handles public GET requests for a media file
+// @Summary Get media file
+// @Description Stream a media library file by its content-addressed key. Conditional and Range requests are supported.
+// @Tags media
+// @Produce image/jpeg,image/png,image/gif,image/webp
+// @Param key path string true "Media key"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200 {file} file "Media file"
+// @Success 206 {file} file "Requested byte range"
+// @Success 304 {string} string "Not modified"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 416 {string} string "Range not satisfiable"
+// @Failure 500 {object} models.ErrorResponse
+// @Router /media/{key} [get]
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	object, err := h.mediaService.OpenMedia(c.Param("key"))
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to load media")
+		return
+	}
+
+	serveAssetObject(c, object)
+}
+
+// ListMedia handles GET requests for the media library
+// @Summary List media
+// @Description Get media library entries, newest first, optionally filtered by search term and type (requires authentication)
+// @Tags media
+// @Security BearerAuth
+// @Produce json
+// @Param q query string false "Search filename and alt text"
+// @Param type query string false "Filter by MIME type" Enums(image/jpeg, image/png, image/gif, image/webp)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Entries per page" default(10)
+// @Success 200 {object} models.MediaListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/media [get]
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	search := strings.TrimSpace(c.Query("q"))
+	if err := h.inputSanitizer.ValidateString(search, "q", 0, 100); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	mimeType := c.Query("type")
+	if mimeType != "" && !isMediaType(mimeType) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "type must be one of " + strings.Join(services.MediaAllowedTypes, ", "),
+		})
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+
+	media, total, err := h.mediaService.ListMedia(search, mimeType, page, pageSize)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load media", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MediaListResponse{
+		Media:      media,
+		Pagination: models.NewPagination(page, pageSize, total),
+	})
+}
+
+// GetMedia handles GET requests for a single media entry
+// @Summary Get media entry
+// @Description Get the metadata of a media library entry by ID (requires authentication)
+// @Tags media
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID"
+// @Success 200 {object} models.Media
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/media/{id} [get]
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	media, err := h.mediaService.GetMedia(id)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to load media")
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+// UploadMedia handles POST requests to add a file to the media library
+// @Summary Upload media
+// @Description Upload an image to the media library. Uploading a file that already exists returns the existing entry. (requires authentication)
+// @Tags media
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image file"
+// @Param alt_text formData string false "Alternative text"
+// @Success 200 {object} models.Media "Identical file already in the library"
+// @Success 201 {object} models.Media
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/media [post]
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "No file uploaded",
+			Message: "Please select a file to upload",
+		})
+		return
+	}
+	defer file.Close()
+
+	fileValidator := utils.NewFileValidator(getMaxFileSize(), 255, services.MediaAllowedTypes)
+
+	if err := fileValidator.ValidateFile(file, header); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "File validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	altText := c.PostForm("alt_text")
+	if err := h.inputSanitizer.ValidateString(altText, "alt_text", 0, 500); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	header.Filename = utils.SanitizeFilename(header.Filename)
+
+	var uploadedBy *int
+	if userID, exists := c.Get("userID"); exists {
+		adminID := userID.(int)
+		uploadedBy = &adminID
+	}
+
+	media, created, err := h.mediaService.UploadMedia(file, header, optionalString(&altText), uploadedBy)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidImage) {
+			respondInvalidImage(c)
+			return
+		}
+		h.errorHandler.HandleError(c, err, "Failed to upload media", utils.ErrorLevelError)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		recordAuditChange(c, "media_uploaded", auditTargetMedia, media.ID, nil, media)
+	}
+
+	c.JSON(status, media)
+}
+
+// DeleteMedia handles DELETE requests to remove a media entry
+// @Summary Delete media
+// @Description Delete a media library entry and its stored file (requires authentication)
+// @Tags media
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/media/{id} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.mediaService.DeleteMedia(id); err != nil {
+		h.handleServiceError(c, err, "Failed to delete media")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Media deleted successfully",
+	})
+}
+
+func (h *MediaHandler) handleServiceError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrMediaNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Media not found",
+			Message: "No media exists with the given identifier",
+		})
+		return
+	}
+
+	h.errorHandler.HandleError(c, err, message, utils.ErrorLevelError)
+}
+
+func isMediaType(mimeType string) bool {
+	for _, allowed := range services.MediaAllowedTypes {
+		if mimeType == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MFAHandler struct {
+	mfaService     *services.MFAService
+	errorHandler   *utils.ErrorHandler
+	securityLogger *utils.SecurityLogger
+}
+
+func NewMFAHandler(mfaService *services.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService:     mfaService,
+		errorHandler:   utils.NewErrorHandler(),
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// GetStatus handles GET requests for the current admin's two-factor settings
+// @Summary Get two-factor status
+// @Description Report whether TOTP is enabled for the authenticated admin and how many recovery codes are left
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.MFAStatusResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/mfa [get]
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	status, err := h.mfaService.Status(currentAdmin(c))
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load two-factor status", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SetupTOTP handles POST requests to start TOTP enrolment
+// @Summary Start TOTP enrolment
+// @Description Generate a TOTP secret and otpauth:// provisioning URI for the authenticated admin to scan as a QR code. TOTP is not required at login until a code is confirmed. Calling this again replaces an unconfirmed secret.
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.TOTPSetupResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/mfa/totp/setup [post]
+func (h *MFAHandler) SetupTOTP(c *gin.Context) {
+	enrolment, err := h.mfaService.BeginTOTPEnrolment(currentAdmin(c))
+	if err != nil {
+		h.handleMFAError(c, err, "Failed to start two-factor enrolment")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TOTPSetupResponse{
+		Secret:          enrolment.Secret,
+		ProvisioningURI: enrolment.ProvisioningURI,
+	})
+}
+
+// ConfirmTOTP handles POST requests to finish TOTP enrolment
+// @Summary Confirm TOTP enrolment
+// @Description Verify a first code from the authenticator app and enable TOTP. The recovery codes in the response are only shown once.
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param mfaCodeRequest body models.MFACodeRequest true "Code from the authenticator app"
+// @Success 200 {object} models.RecoveryCodesResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTP(c *gin.Context) {
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	admin := currentAdmin(c)
+	recoveryCodes, err := h.mfaService.ConfirmTOTPEnrolment(admin, req.Code)
+	if err != nil {
+		h.handleMFAError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_totp_enabled", admin, nil)
+
+	c.JSON(http.StatusOK, models.RecoveryCodesResponse{
+		Message:       "Two-factor authentication enabled",
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// DisableTOTP handles POST requests to turn TOTP off
+// @Summary Disable TOTP
+// @Description Disable TOTP for the authenticated admin. Requires a current authenticator code or an unused recovery code; all recovery codes are discarded.
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param mfaCodeRequest body models.MFACodeRequest true "Authenticator code or recovery code"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/mfa/totp/disable [post]
+func (h *MFAHandler) DisableTOTP(c *gin.Context) {
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	admin := currentAdmin(c)
+	if err := h.mfaService.DisableTOTP(admin, req.Code); err != nil {
+		h.handleMFAError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_totp_disabled", admin, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+func (h *MFAHandler) handleMFAError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidMFACode):
+		h.securityLogger.LogSecurityEvent("admin_mfa_code_rejected", map[string]interface{}{
+			"admin_id":  currentAdmin(c).ID,
+			"client_ip": c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid code",
+			Message: "The two-factor code is invalid or has already been used",
+		})
+	case errors.Is(err, services.ErrMFAAlreadyEnabled):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Two-factor already enabled",
+			Message: "Disable two-factor authentication before enrolling a new authenticator",
+		})
+	case errors.Is(err, services.ErrMFANotEnabled):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Two-factor not enabled",
+			Message: "Two-factor authentication is not enabled for this account",
+		})
+	case errors.Is(err, services.ErrMFANotPending):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "No enrolment in progress",
+			Message: "Start two-factor enrolment before confirming a code",
+		})
+	default:
+		h.errorHandler.HandleError(c, err, message, utils.ErrorLevelError)
+	}
+}
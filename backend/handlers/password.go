@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordHandler struct {
+	passwordService *services.PasswordService
+	adminRepo       *repository.AdminRepository
+	inputSanitizer  *utils.InputSanitizer
+	errorHandler    *utils.ErrorHandler
+	securityLogger  *utils.SecurityLogger
+}
+
+func NewPasswordHandler(passwordService *services.PasswordService, adminRepo *repository.AdminRepository) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+		adminRepo:       adminRepo,
+		inputSanitizer:  utils.NewInputSanitizer(1000),
+		errorHandler:    utils.NewErrorHandler(),
+		securityLogger:  utils.NewSecurityLogger(),
+	}
+}
+
+// ChangePassword handles POST requests to change the current admin's password
+// @Summary Change own password
+// @Description Change the authenticated admin's password after confirming the current one. Every other session is signed out.
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param changePasswordRequest body models.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/password [post]
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.inputSanitizer.ValidatePassword(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	admin := currentAdmin(c)
+	if err := h.passwordService.ChangePassword(admin, c.GetString("sessionID"), req.CurrentPassword, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, services.ErrIncorrectPassword):
+			message := "The current password is incorrect"
+			if admin.HashVersion == 1 {
+				message = "This account uses a legacy password format and must be reset with a reset token"
+			}
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Incorrect password",
+				Message: message,
+			})
+		case errors.Is(err, services.ErrPasswordUnchanged):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid input",
+				Message: "The new password must differ from the current password",
+			})
+		default:
+			h.errorHandler.HandleError(c, err, "Failed to change password", utils.ErrorLevelError)
+		}
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_password_changed", admin, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Password changed successfully",
+	})
+}
+
+// IssuePasswordReset handles POST requests to create a reset token for an admin
+// @Summary Issue password reset token
+// @Description Create a single-use, time-limited password reset token for an admin (requires authentication). The token is only returned once; any earlier token for the same admin stops working.
+// @Tags admin-users
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 201 {object} models.PasswordResetTokenResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/users/{id}/password-reset [post]
+func (h *PasswordHandler) IssuePasswordReset(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	admin, err := h.adminRepo.GetAdminByID(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to issue reset token", utils.ErrorLevelError)
+		return
+	}
+
+	if admin == nil {
+		respondAdminNotFound(c)
+		return
+	}
+
+	actorID := currentAdmin(c).ID
+	token, expiresAt, err := h.passwordService.IssueResetToken(admin.ID, &actorID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to issue reset token", utils.ErrorLevelError)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_password_reset_issued", admin, map[string]interface{}{
+		"expires_at": expiresAt,
+	})
+
+	c.JSON(http.StatusCreated, models.PasswordResetTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// ResetPassword handles POST requests to redeem a password reset token
+// @Summary Reset password
+// @Description Set a new password using a reset token. This also upgrades accounts that still use the legacy password format, and signs out every session.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param resetPasswordRequest body models.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Token, "token", 1, 128); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.inputSanitizer.ValidatePassword(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	admin, err := h.passwordService.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidResetToken) {
+			h.securityLogger.LogSecurityEvent("admin_password_reset_rejected", map[string]interface{}{
+				"client_ip":  c.ClientIP(),
+				"user_agent": c.GetHeader("User-Agent"),
+			})
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid reset token",
+				Message: "The reset token is invalid, has already been used or has expired",
+			})
+			return
+		}
+		h.errorHandler.HandleError(c, err, "Failed to reset password", utils.ErrorLevelError)
+		return
+	}
+
+	// The reset token identifies the admin, since the request is not signed in
+	utils.SetAuditActor(c, admin)
+	auditAdminAction(c, h.securityLogger, "admin_password_reset", admin, nil)
+
+	clearAuthCookies(c)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Password reset successfully, please log in with the new password",
+	})
+}
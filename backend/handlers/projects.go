@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectRepo    *repository.ProjectRepository
+	inputSanitizer *utils.InputSanitizer
+	errorHandler   *utils.ErrorHandler
+}
+
+func NewProjectHandler(projectRepo *repository.ProjectRepository) *ProjectHandler {
+	return &ProjectHandler{
+		projectRepo:    projectRepo,
+		inputSanitizer: utils.NewInputSanitizer(1000),
+		errorHandler:   utils.NewErrorHandler(),
+	}
+}
+
+// ListProjects handles GET requests for all projects
+// @Summary List projects
+// @Description Get all portfolio projects ordered by sort order and last update
+// @Tags projects
+// @Produce json
+// @Param featured query bool false "Only return featured projects"
+// @Success 200 {object} models.ProjectListResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /projects [get]
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	featuredOnly := c.Query("featured") == "true"
+
+	projects, err := h.projectRepo.ListProjects(featuredOnly)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load projects", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ProjectListResponse{
+		Projects: projects,
+		Total:    len(projects),
+	})
+}
+
+// GetProject handles GET requests for a single project
+// @Summary Get project by slug
+// @Description Get a single portfolio project by its slug
+// @Tags projects
+// @Produce json
+// @Param slug path string true "Project slug"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /projects/{slug} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	slug := c.Param("slug")
+	if err := h.inputSanitizer.ValidateSlug(slug); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	project, err := h.projectRepo.GetProjectBySlug(slug)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load project", utils.ErrorLevelError)
+		return
+	}
+
+	if project == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Project not found",
+			Message: "No project exists with the given slug",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// CreateProject handles POST requests to create a project
+// @Summary Create project
+// @Description Create a new portfolio project (requires authentication)
+// @Tags projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param project body models.ProjectRequest true "Project details"
+// @Success 201 {object} models.Project
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	project, ok := h.bindProjectRequest(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.projectRepo.CreateProject(project)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	recordAuditChange(c, "project_created", auditTargetProject, created.ID, nil, created)
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateProject handles PUT requests to update a project
+// @Summary Update project
+// @Description Replace an existing portfolio project (requires authentication)
+// @Tags projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param project body models.ProjectRequest true "Project details"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	project, ok := h.bindProjectRequest(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadProject(c, id, "Failed to update project")
+	if !ok {
+		return
+	}
+
+	updated, err := h.projectRepo.UpdateProject(id, project)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	if updated == nil {
+		respondProjectNotFound(c)
+		return
+	}
+
+	recordAuditChange(c, "project_updated", auditTargetProject, id, previous, updated)
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteProject handles DELETE requests to remove a project
+// @Summary Delete project
+// @Description Delete a portfolio project (requires authentication)
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	previous, ok := h.loadProject(c, id, "Failed to delete project")
+	if !ok {
+		return
+	}
+
+	deleted, err := h.projectRepo.DeleteProject(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to delete project", utils.ErrorLevelError)
+		return
+	}
+
+	if !deleted {
+		respondProjectNotFound(c)
+		return
+	}
+
+	recordAuditChange(c, "project_deleted", auditTargetProject, id, previous, nil)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Project deleted successfully",
+	})
+}
+
+// loadProject fetches a project before it is changed, so the audit log can record its previous
+// state. It responds with a 404 when the project does not exist.
+func (h *ProjectHandler) loadProject(c *gin.Context, id int, message string) (*models.Project, bool) {
+	project, err := h.projectRepo.GetProjectByID(id)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, message, utils.ErrorLevelError)
+		return nil, false
+	}
+
+	if project == nil {
+		respondProjectNotFound(c)
+		return nil, false
+	}
+
+	return project, true
+}
+
+func respondProjectNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Project not found",
+		Message: "No project exists with the given ID",
+	})
+}
+
+func (h *ProjectHandler) bindProjectRequest(c *gin.Context) (*models.Project, bool) {
+	var req models.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	if req.Slug == "" {
+		req.Slug = h.inputSanitizer.SanitizeSlug(req.Title)
+	}
+
+	if err := h.validateProjectRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	techStack := make([]string, 0, len(req.TechStack))
+	for _, tech := range req.TechStack {
+		techStack = append(techStack, strings.TrimSpace(tech))
+	}
+
+	return &models.Project{
+		Slug:          req.Slug,
+		Title:         strings.TrimSpace(req.Title),
+		Summary:       optionalString(req.Summary),
+		Description:   req.Description,
+		ImageURL:      optionalString(req.ImageURL),
+		ImageAlt:      optionalString(req.ImageAlt),
+		TechStack:     techStack,
+		RepositoryURL: optionalString(req.RepositoryURL),
+		LiveURL:       optionalString(req.LiveURL),
+		Featured:      req.Featured,
+		SortOrder:     req.SortOrder,
+	}, true
+}
+
+func (h *ProjectHandler) validateProjectRequest(req *models.ProjectRequest) error {
+	if err := h.inputSanitizer.ValidateSlug(req.Slug); err != nil {
+		return err
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Title, "title", 1, 200); err != nil {
+		return err
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Description, "description", 1, 20000); err != nil {
+		return err
+	}
+
+	if req.Summary != nil {
+		if err := h.inputSanitizer.ValidateString(*req.Summary, "summary", 0, 500); err != nil {
+			return err
+		}
+	}
+
+	if req.ImageAlt != nil {
+		if err := h.inputSanitizer.ValidateString(*req.ImageAlt, "image_alt", 0, 255); err != nil {
+			return err
+		}
+	}
+
+	if len(req.TechStack) > 30 {
+		return utils.NewValidationError("tech_stack", "must not contain more than %d entries", 30)
+	}
+
+	for _, tech := range req.TechStack {
+		if err := h.inputSanitizer.ValidateString(tech, "tech_stack", 1, 50); err != nil {
+			return err
+		}
+	}
+
+	urls := map[string]*string{
+		"image_url":      req.ImageURL,
+		"repository_url": req.RepositoryURL,
+		"live_url":       req.LiveURL,
+	}
+	for fieldName, value := range urls {
+		if value == nil || *value == "" {
+			continue
+		}
+		if err := h.inputSanitizer.ValidateURL(*value, fieldName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *ProjectHandler) handleWriteError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrDuplicateSlug) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Slug already in use",
+			Message: "Another project already uses this slug",
+		})
+		return
+	}
+
+	h.errorHandler.HandleError(c, err, "Failed to save project", utils.ErrorLevelError)
+}
+
+func parseIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: "id must be a positive integer",
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func optionalString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+
+	return &trimmed
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService *services.SessionService
+	errorHandler   *utils.ErrorHandler
+	securityLogger *utils.SecurityLogger
+}
+
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		errorHandler:   utils.NewErrorHandler(),
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// ListSessions handles GET requests for the current admin's sessions
+// @Summary List sessions
+// @Description Get the authenticated admin's active sessions, most recently used first. The session making the request is flagged as current.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.SessionListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.sessionService.ListSessions(currentAdmin(c).ID, c.GetString("sessionID"))
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load sessions", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SessionListResponse{Sessions: sessions})
+}
+
+// RevokeSession handles DELETE requests for one of the current admin's sessions
+// @Summary Revoke session
+// @Description Sign out one of the authenticated admin's sessions. Revoking the current session logs the caller out.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	admin := currentAdmin(c)
+	sessionID := c.Param("id")
+
+	revoked, err := h.sessionService.RevokeSession(admin.ID, sessionID, services.RevocationReasonSessionRevoked)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke session", utils.ErrorLevelError)
+		return
+	}
+
+	if !revoked {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Session not found",
+			Message: "No active session exists with the given ID",
+		})
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_session_revoked", admin, map[string]interface{}{
+		"session_id": sessionID,
+	})
+
+	if sessionID == c.GetString("sessionID") {
+		clearAuthCookies(c)
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// RevokeOtherSessions handles DELETE requests for every session except the current one
+// @Summary Revoke other sessions
+// @Description Sign out every session of the authenticated admin except the one making the request
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.RevokeSessionsResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/sessions [delete]
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	admin := currentAdmin(c)
+
+	revoked, err := h.sessionService.RevokeOtherSessions(admin.ID, c.GetString("sessionID"), services.RevocationReasonSessionRevoked)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to revoke sessions", utils.ErrorLevelError)
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_sessions_revoked", admin, map[string]interface{}{
+		"revoked_sessions": revoked,
+	})
+
+	c.JSON(http.StatusOK, models.RevokeSessionsResponse{
+		Message: "Other sessions revoked successfully",
+		Revoked: revoked,
+	})
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WebAuthnHandler struct {
+	webauthnService *services.WebAuthnService
+	adminHandler    *AdminHandler
+	inputSanitizer  *utils.InputSanitizer
+	errorHandler    *utils.ErrorHandler
+	securityLogger  *utils.SecurityLogger
+}
+
+// NewWebAuthnHandler takes the AdminHandler so passkey logins go through the same lockout,
+// session and login logging as password logins
+func NewWebAuthnHandler(webauthnService *services.WebAuthnService, adminHandler *AdminHandler) *WebAuthnHandler {
+	return &WebAuthnHandler{
+		webauthnService: webauthnService,
+		adminHandler:    adminHandler,
+		inputSanitizer:  utils.NewInputSanitizer(1000),
+		errorHandler:    utils.NewErrorHandler(),
+		securityLogger:  utils.NewSecurityLogger(),
+	}
+}
+
+// BeginRegistration handles POST requests to start adding a passkey
+// @Summary Start passkey registration
+// @Description Get the options to pass to navigator.credentials.create() for the authenticated admin. The ceremony expires after five minutes.
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.WebAuthnOptionsResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/register/begin [post]
+func (h *WebAuthnHandler) BeginRegistration(c *gin.Context) {
+	options, err := h.webauthnService.BeginRegistration(currentAdmin(c))
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to start passkey registration", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebAuthnOptionsResponse{
+		CeremonyID: options.CeremonyID,
+		Options:    options.Options,
+	})
+}
+
+// FinishRegistration handles POST requests to store a new passkey
+// @Summary Finish passkey registration
+// @Description Verify the authenticator's response from navigator.credentials.create() and save the passkey
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webAuthnRegistrationRequest body models.WebAuthnRegistrationRequest true "Ceremony ID, passkey name and authenticator response"
+// @Success 201 {object} models.WebAuthnCredential
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/register/finish [post]
+func (h *WebAuthnHandler) FinishRegistration(c *gin.Context) {
+	var req models.WebAuthnRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.inputSanitizer.ValidateString(req.Name, "name", 1, 100); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid input",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	admin := currentAdmin(c)
+	credential, err := h.webauthnService.FinishRegistration(admin, req.CeremonyID, req.Name, req.Credential)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWebAuthnCeremonyInvalid):
+			respondCeremonyInvalid(c)
+		case errors.Is(err, services.ErrWebAuthnVerificationFailed):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Passkey verification failed",
+				Message: "The authenticator response could not be verified",
+			})
+		case errors.Is(err, repository.ErrDuplicateCredential):
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error:   "Passkey already registered",
+				Message: "This passkey is already registered",
+			})
+		default:
+			h.errorHandler.HandleError(c, err, "Failed to register passkey", utils.ErrorLevelError)
+		}
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_passkey_added", admin, map[string]interface{}{
+		"credential_id": credential.ID,
+		"name":          credential.Name,
+	})
+
+	c.JSON(http.StatusCreated, credential)
+}
+
+// ListCredentials handles GET requests for the current admin's passkeys
+// @Summary List passkeys
+// @Description Get the passkeys registered to the authenticated admin
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.WebAuthnCredentialListResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/credentials [get]
+func (h *WebAuthnHandler) ListCredentials(c *gin.Context) {
+	credentials, err := h.webauthnService.ListCredentials(currentAdmin(c).ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to load passkeys", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebAuthnCredentialListResponse{Credentials: credentials})
+}
+
+// DeleteCredential handles DELETE requests for one of the current admin's passkeys
+// @Summary Remove passkey
+// @Description Remove a passkey from the authenticated admin's account
+// @Tags admin-mfa
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Passkey ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/credentials/{id} [delete]
+func (h *WebAuthnHandler) DeleteCredential(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	admin := currentAdmin(c)
+	deleted, err := h.webauthnService.DeleteCredential(id, admin.ID)
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to remove passkey", utils.ErrorLevelError)
+		return
+	}
+
+	if !deleted {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Passkey not found",
+			Message: "No passkey exists with the given ID",
+		})
+		return
+	}
+
+	auditAdminAction(c, h.securityLogger, "admin_passkey_removed", admin, map[string]interface{}{
+		"credential_id": id,
+	})
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: "Passkey removed successfully",
+	})
+}
+
+// BeginLogin handles POST requests to start a passkey login
+// @Summary Start passkey login
+// @Description Get the options to pass to navigator.credentials.get(). No username is needed; the passkey identifies the admin.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} models.WebAuthnOptionsResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/login/begin [post]
+func (h *WebAuthnHandler) BeginLogin(c *gin.Context) {
+	options, err := h.webauthnService.BeginLogin()
+	if err != nil {
+		h.errorHandler.HandleError(c, err, "Failed to start passkey login", utils.ErrorLevelError)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebAuthnOptionsResponse{
+		CeremonyID: options.CeremonyID,
+		Options:    options.Options,
+	})
+}
+
+// FinishLogin handles POST requests to log in with a passkey
+// @Summary Finish passkey login
+// @Description Verify the authenticator's response from navigator.credentials.get() and log in. Passkeys require user verification, so no TOTP code is asked for.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param webAuthnLoginRequest body models.WebAuthnLoginRequest true "Ceremony ID and authenticator response"
+// @Success 200 {object} models.LoginResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webauthn/login/finish [post]
+func (h *WebAuthnHandler) FinishLogin(c *gin.Context) {
+	var req models.WebAuthnLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.adminHandler.logLoginAttempt(c, false, fmt.Sprintf("Invalid request format: %v", err))
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// The admin is only known once the assertion is verified, so lockout is checked by IP alone
+	if _, ok := h.adminHandler.claimLoginAttempt(c, ""); !ok {
+		return
+	}
+
+	admin, err := h.webauthnService.FinishLogin(req.CeremonyID, req.Credential)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWebAuthnCeremonyInvalid):
+			h.adminHandler.logLoginAttempt(c, false, "Passkey login ceremony invalid or expired")
+			respondCeremonyInvalid(c)
+		case errors.Is(err, auth.ErrWebAuthnCloneWarning):
+			h.securityLogger.LogSecurityEvent("admin_passkey_clone_warning", map[string]interface{}{
+				"admin_id":  admin.ID,
+				"username":  admin.Username,
+				"client_ip": c.ClientIP(),
+			})
+			h.adminHandler.logLoginAttempt(c, false, "Passkey rejected: signature counter went backwards")
+			h.adminHandler.recordLoginFailure(c, "")
+			h.errorHandler.HandleAuthError(c, err, "Passkey verification failed")
+		case errors.Is(err, services.ErrWebAuthnVerificationFailed):
+			h.adminHandler.logLoginAttempt(c, false, "Passkey verification failed")
+			h.adminHandler.recordLoginFailure(c, "")
+			h.errorHandler.HandleAuthError(c, err, "Passkey verification failed")
+		default:
+			h.adminHandler.logLoginAttempt(c, false, fmt.Sprintf("Failed to verify passkey: %v", err))
+			h.errorHandler.HandleError(c, err, "Failed to process login request", utils.ErrorLevelError)
+		}
+		return
+	}
+
+	h.adminHandler.completeLogin(c, admin, "Login successful using a passkey")
+}
+
+func respondCeremonyInvalid(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "Invalid ceremony",
+		Message: "The passkey request is invalid or has expired, please try again",
+	})
+}
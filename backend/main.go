@@ -31,6 +31,7 @@ import (
 	"github.com/Wildcard209/portfolio-webapplication/auth"
 	"github.com/Wildcard209/portfolio-webapplication/config"
 	_ "github.com/Wildcard209/portfolio-webapplication/docs"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
 	"github.com/Wildcard209/portfolio-webapplication/routes"
 	"github.com/Wildcard209/portfolio-webapplication/services"
 	"github.com/gin-gonic/gin"
@@ -66,22 +67,32 @@ func main() {
 	}
 	defer cfg.Close()
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
+	if cfg.JWT.Secret == "" && cfg.JWT.SigningKeyFile == "" {
 		if os.Getenv("GIN_MODE") == "release" {
-			log.Fatal("JWT_SECRET environment variable must be set in production")
+			log.Fatal("JWT_SIGNING_KEY_FILE or JWT_SECRET environment variable must be set in production")
 		} else {
-			jwtSecret = "your-super-secret-jwt-key-change-this-in-production"
-			log.Println("Warning: Using default JWT secret. Please set JWT_SECRET environment variable.")
+			cfg.JWT.Secret = "your-super-secret-jwt-key-change-this-in-production"
+			log.Println("Warning: Using default JWT secret. Please set JWT_SIGNING_KEY_FILE or JWT_SECRET environment variable.")
 		}
 	}
 
-	authService := auth.NewAuthService(jwtSecret, 1*time.Hour)
+	jwtKeys, err := auth.LoadKeySet(cfg.JWT.Secret, cfg.JWT.SigningKeyFile, cfg.JWT.VerificationKeyFiles)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
+	// Without a database, revocations only last until the process restarts
+	var revocationStore auth.RevocationStore
+	if cfg.DB != nil {
+		revocationStore = repository.NewRevokedTokenRepository(cfg.DB)
+	}
+
+	authService := auth.NewAuthService(jwtKeys, auth.NewRevocationList(revocationStore), 1*time.Hour)
 
 	cfg.RateLimit = config.LoadRateLimitConfig()
 
 	if cfg.DB != nil {
-		adminService := services.NewAdminService(cfg.DB, authService)
+		adminService := services.NewAdminService(cfg.DB, authService, cfg.LoginProtection, cfg.Audit)
 		if err := adminService.InitializeAdminSystem(); err != nil {
 			log.Fatalf("Failed to initialize admin system: %v", err)
 		}
@@ -93,6 +104,10 @@ func main() {
 
 	r := gin.New()
 
+	if err := setTrustedProxies(r, cfg.IPAccess.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	if os.Getenv("GIN_MODE") == "release" {
 		r.Use(gin.LoggerWithConfig(gin.LoggerConfig{
 			SkipPaths: []string{"/api/health"},
@@ -136,3 +151,14 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// setTrustedProxies limits which proxies may report the client IP with X-Forwarded-For. Gin
+// trusts every proxy by default, which would let clients that reach the backend directly pick
+// their IP and get past the IP lists, IP blocks and login lockouts, so with none configured only
+// the connecting address is used.
+func setTrustedProxies(r *gin.Engine, proxies []string) error {
+	if len(proxies) == 0 {
+		log.Println("Warning: TRUSTED_PROXIES is not set, so X-Forwarded-For is ignored and clients are identified by the address connecting to the backend.")
+	}
+	return r.SetTrustedProxies(proxies)
+}
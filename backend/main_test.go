@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetTrustedProxiesClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		want       string
+	}{
+		{"unset ignores a spoofed header", nil, "198.51.100.7:40000", "198.51.100.7"},
+		{"untrusted peer ignores the header", []string{"172.16.0.0/12"}, "198.51.100.7:40000", "198.51.100.7"},
+		{"trusted proxy reports the client", []string{"172.16.0.0/12"}, "172.18.0.5:40000", "203.0.113.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			if err := setTrustedProxies(r, tt.proxies); err != nil {
+				t.Fatalf("setTrustedProxies: %v", err)
+			}
+
+			var clientIP string
+			r.GET("/", func(c *gin.Context) {
+				clientIP = c.ClientIP()
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "203.0.113.9")
+			req.Header.Set("X-Real-IP", "203.0.113.9")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if clientIP != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", clientIP, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTrustedProxiesRejectsInvalidEntries(t *testing.T) {
+	if err := setTrustedProxies(gin.New(), []string{"not-a-proxy"}); err == nil {
+		t.Error("expected an error for an invalid proxy")
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// IPAccessMiddleware rejects clients that the IP allow and deny lists or a temporary block keep
+// out. Requests under /api/admin are checked against the admin lists, everything else against
+// the public ones. It checks the request path rather than the matched route, so unknown admin
+// paths cannot be used to probe the API from outside the allowed ranges.
+func IPAccessMiddleware(ipAccess *services.IPAccessService) gin.HandlerFunc {
+	securityLogger := utils.NewSecurityLogger()
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		admin := path == "/api/admin" || strings.HasPrefix(path, adminRoutePrefix)
+
+		clientIP := c.ClientIP()
+		allowed, reason := ipAccess.Check(net.ParseIP(clientIP), admin)
+		if allowed {
+			c.Next()
+			return
+		}
+
+		scope := "public"
+		if admin {
+			scope = "admin"
+		}
+
+		securityLogger.LogSecurityEvent("ip_access_denied", map[string]interface{}{
+			"client_ip":  clientIP,
+			"scope":      scope,
+			"reason":     reason,
+			"method":     c.Request.Method,
+			"path":       path,
+			"user_agent": c.GetHeader("User-Agent"),
+		})
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		c.Abort()
+	}
+}
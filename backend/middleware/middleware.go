@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,7 +11,8 @@ import (
 
 	"github.com/Wildcard209/portfolio-webapplication/auth"
 	"github.com/Wildcard209/portfolio-webapplication/config"
-	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/ulule/limiter/v3"
 	mgin "github.com/ulule/limiter/v3/drivers/middleware/gin"
@@ -24,8 +26,16 @@ func RateLimitMiddleware(rate limiter.Rate) gin.HandlerFunc {
 	return mgin.NewMiddleware(rateLimiter)
 }
 
-func AuthMiddleware(authService *auth.AuthService, adminRepo *repository.AdminRepository) gin.HandlerFunc {
+// AuthMiddleware authenticates admins by access token, refreshing the session from the refresh
+// cookie when the token has expired. When apiKeyService is set it also accepts an
+// "Authorization: ApiKey <key>" header; see RequireSession for routes keys may not use.
+func AuthMiddleware(authService *auth.AuthService, sessionService *services.SessionService, apiKeyService *services.APIKeyService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawKey, ok := apiKeyFromHeader(c.GetHeader("Authorization")); ok && apiKeyService != nil {
+			authenticateAPIKey(c, apiKeyService, rawKey)
+			return
+		}
+
 		var tokenString string
 		var err error
 
@@ -57,95 +67,157 @@ func AuthMiddleware(authService *auth.AuthService, adminRepo *repository.AdminRe
 				return
 			}
 
-			refreshClaims, refreshValidErr := authService.ValidateRefreshToken(refreshToken)
-			if refreshValidErr != nil {
-				isHttps := os.Getenv("HTTPS_MODE") == "true"
-				c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
-				c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session expired, please login again"})
+			session, sessionErr := sessionService.RefreshSession(refreshToken, c.ClientIP(), c.GetHeader("User-Agent"))
+			if sessionErr != nil {
+				if errors.Is(sessionErr, services.ErrSessionRevoked) {
+					clearAuthCookies(c)
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "Session expired, please login again"})
+				} else {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session"})
+				}
 				c.Abort()
 				return
 			}
 
-			admin, adminErr := adminRepo.GetAdminByToken(refreshToken)
-			if adminErr != nil || admin == nil {
+			// A concurrent request already rotated the session and set the new cookies
+			if tokenPair := session.Tokens; tokenPair != nil {
 				isHttps := os.Getenv("HTTPS_MODE") == "true"
-				c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
-				c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
-				c.Abort()
-				return
+				c.SetCookie(
+					"access_token",
+					tokenPair.AccessToken,
+					int(tokenPair.AccessExpiresAt.Sub(time.Now()).Seconds()),
+					"/",
+					"",
+					isHttps,
+					true,
+				)
+
+				c.SetCookie(
+					"refresh_token",
+					tokenPair.RefreshToken,
+					int(tokenPair.RefreshExpiresAt.Sub(time.Now()).Seconds()),
+					"/",
+					"",
+					isHttps,
+					true,
+				)
 			}
 
-			tokenPair, tokenErr := authService.GenerateTokenPair(refreshClaims.UserID, refreshClaims.Username)
-			if tokenErr != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session"})
-				c.Abort()
-				return
-			}
-
-			if updateErr := adminRepo.UpdateAdminToken(refreshClaims.UserID, tokenPair.RefreshToken, tokenPair.RefreshExpiresAt); updateErr != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update session"})
-				c.Abort()
-				return
-			}
-
-			isHttps := os.Getenv("HTTPS_MODE") == "true"
-			c.SetCookie(
-				"access_token",
-				tokenPair.AccessToken,
-				int(tokenPair.AccessExpiresAt.Sub(time.Now()).Seconds()),
-				"/",
-				"",
-				isHttps,
-				true,
-			)
-
-			c.SetCookie(
-				"refresh_token",
-				tokenPair.RefreshToken,
-				int(tokenPair.RefreshExpiresAt.Sub(time.Now()).Seconds()),
-				"/",
-				"",
-				isHttps,
-				true,
-			)
-
-			claims = &auth.CustomClaims{
-				UserID:    refreshClaims.UserID,
-				Username:  refreshClaims.Username,
-				TokenType: "access",
-			}
-
-			c.Set("userID", claims.UserID)
-			c.Set("username", claims.Username)
-			c.Set("admin", admin)
+			c.Set("userID", session.Admin.ID)
+			c.Set("username", session.Admin.Username)
+			c.Set("sessionID", session.SessionID)
+			c.Set("admin", session.Admin)
+			c.Set("role", session.Admin.Role)
+			c.Set("permissions", auth.PermissionsForRole(session.Admin.Role))
 
 			c.Next()
 			return
 		}
 
-		admin, err := adminRepo.GetAdminByID(claims.UserID)
+		admin, err := sessionService.ValidateSession(claims, c.ClientIP())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+			if errors.Is(err, services.ErrSessionRevoked) {
+				clearAuthCookies(c)
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+			}
 			c.Abort()
 			return
 		}
 
-		if admin == nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		// The username is taken from the database since it may have changed since the token was issued
+		c.Set("userID", admin.ID)
+		c.Set("username", admin.Username)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("admin", admin)
+		c.Set("role", admin.Role)
+		c.Set("permissions", auth.EffectivePermissions(claims, admin.Role))
+
+		c.Next()
+	}
+}
+
+const apiKeyScheme = "ApiKey "
+
+func apiKeyFromHeader(authHeader string) (string, bool) {
+	if len(authHeader) <= len(apiKeyScheme) || !strings.EqualFold(authHeader[:len(apiKeyScheme)], apiKeyScheme) {
+		return "", false
+	}
+	return strings.TrimSpace(authHeader[len(apiKeyScheme):]), true
+}
+
+func authenticateAPIKey(c *gin.Context, apiKeyService *services.APIKeyService, rawKey string) {
+	principal, err := apiKeyService.Authenticate(rawKey, c.ClientIP())
+	if err != nil {
+		if errors.Is(err, services.ErrAPIKeyInvalid) {
+			utils.NewSecurityLogger().LogSecurityEvent("api_key_rejected", map[string]interface{}{
+				"client_ip": c.ClientIP(),
+				"path":      c.Request.URL.Path,
+			})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify API key"})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("userID", principal.Admin.ID)
+	c.Set("username", principal.Admin.Username)
+	c.Set("admin", principal.Admin)
+	c.Set("role", principal.Admin.Role)
+	c.Set("permissions", principal.Permissions)
+	c.Set("apiKeyID", principal.Key.ID)
+
+	c.Next()
+}
+
+// RequireSession rejects requests authenticated with an API key. Account and security routes
+// use it so a leaked key cannot change the password, sign-in methods or other keys.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAPIKey := c.Get("apiKeyID"); isAPIKey {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This action requires signing in, API keys cannot be used"})
 			c.Abort()
 			return
 		}
 
-		c.Set("userID", claims.UserID)
-		c.Set("username", claims.Username)
-		c.Set("admin", admin)
-
 		c.Next()
 	}
 }
 
+// RequirePermission rejects requests whose access token does not grant permission.
+// It must run after AuthMiddleware.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permissions := c.GetStringSlice("permissions")
+		if auth.HasPermission(permissions, permission) {
+			c.Next()
+			return
+		}
+
+		utils.NewSecurityLogger().LogSecurityEvent("permission_denied", map[string]interface{}{
+			"admin_id":   c.GetInt("userID"),
+			"api_key_id": c.GetInt("apiKeyID"),
+			"role":       c.GetString("role"),
+			"permission": permission,
+			"method":     c.Request.Method,
+			"path":       c.FullPath(),
+			"client_ip":  c.ClientIP(),
+		})
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+		c.Abort()
+	}
+}
+
+func clearAuthCookies(c *gin.Context) {
+	isHttps := os.Getenv("HTTPS_MODE") == "true"
+	c.SetCookie("access_token", "", -1, "/", "", isHttps, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", isHttps, true)
+}
+
 func SecurityHeadersMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if !cfg.SecurityHeaders.Enabled {
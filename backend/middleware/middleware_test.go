@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireSessionRefusesAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		apiKey bool
+		want   int
+	}{
+		{"session", false, http.StatusOK},
+		{"API key", true, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.apiKey {
+					c.Set("apiKeyID", 7)
+				}
+			})
+			router.POST("/api/admin/password", RequireSession(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/admin/password", nil))
+
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRejectsRevokedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	keys, err := auth.LoadKeySet("middleware-test-secret", "", nil)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	authService := auth.NewAuthService(keys, auth.NewRevocationList(nil), time.Hour)
+
+	pair, err := authService.GenerateTokenPair(1, "admin", models.AdminRoleOwner, "session")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair: %v", err)
+	}
+	if err := authService.RevokeAccessToken(models.RevokedToken{TokenID: pair.AccessTokenID, ExpiresAt: pair.AccessExpiresAt}); err != nil {
+		t.Fatalf("RevokeAccessToken: %v", err)
+	}
+
+	// The token is rejected before the session is looked up, so no session service is needed
+	router := gin.New()
+	router.GET("/api/admin/projects", AuthMiddleware(authService, nil, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/projects", nil)
+	req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusUnauthorized)
+	}
+	if !pair.AccessExpiresAt.After(time.Now()) {
+		t.Error("token expired during the test, so the revocation was not what rejected it")
+	}
+}
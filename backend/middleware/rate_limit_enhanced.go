@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Wildcard209/portfolio-webapplication/config"
@@ -23,6 +24,7 @@ const (
 	RateLimitAPI     RateLimitType = "api"
 	RateLimitPublic  RateLimitType = "public"
 	RateLimitAdmin   RateLimitType = "admin"
+	RateLimitContact RateLimitType = "contact"
 )
 
 func RateLimitMiddlewareWithConfig(rateLimitType RateLimitType, rateLimitConfig *config.EnhancedRateLimitConfig) gin.HandlerFunc {
@@ -41,6 +43,8 @@ func RateLimitMiddlewareWithConfig(rateLimitType RateLimitType, rateLimitConfig
 		rateLimit = rateLimitConfig.Public
 	case RateLimitAdmin:
 		rateLimit = rateLimitConfig.Admin
+	case RateLimitContact:
+		rateLimit = rateLimitConfig.Contact
 	default:
 		rateLimit = rateLimitConfig.API
 	}
@@ -53,11 +57,53 @@ func RateLimitMiddlewareWithConfig(rateLimitType RateLimitType, rateLimitConfig
 
 		logRateLimitAttempt(c, string(rateLimitType), rateLimit)
 
-		middleware := mgin.NewMiddleware(rateLimiter)
+		middleware := mgin.NewMiddleware(rateLimiter, mgin.WithKeyGetter(rateLimitKey), mgin.WithLimitReachedHandler(limitReached))
 		middleware(c)
 	}
 }
 
+// APIKeyRateLimitMiddleware caps the total request rate of each API key across every route,
+// using the RATE_LIMIT_API settings. Requests not made with an API key pass straight through.
+// It must run after AuthMiddleware.
+func APIKeyRateLimitMiddleware(rateLimitConfig *config.EnhancedRateLimitConfig) gin.HandlerFunc {
+	rateLimit := rateLimitConfig.API
+	rateLimiter := limiter.New(memory.NewStore(), rateLimit.ToLimiterRate())
+	middleware := mgin.NewMiddleware(rateLimiter, mgin.WithKeyGetter(rateLimitKey), mgin.WithLimitReachedHandler(limitReached))
+
+	return func(c *gin.Context) {
+		if _, isAPIKey := c.Get("apiKeyID"); !isAPIKey {
+			c.Next()
+			return
+		}
+
+		logRateLimitAttempt(c, string(RateLimitAPI), rateLimit)
+		middleware(c)
+	}
+}
+
+// rateLimitKey gives each API key its own bucket, so a CI job does not share a limit with
+// people signing in from the same address. Everything else is limited per client IP.
+func rateLimitKey(c *gin.Context) string {
+	if keyID := c.GetInt("apiKeyID"); keyID != 0 {
+		return fmt.Sprintf("api_key:%d", keyID)
+	}
+	return c.ClientIP()
+}
+
+// limitReached tells the client when its window resets, from the X-RateLimit-Reset header the
+// limiter has just set
+func limitReached(c *gin.Context) {
+	retryAfter := int64(1)
+	if reset, err := strconv.ParseInt(c.Writer.Header().Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := reset - time.Now().Unix(); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	mgin.DefaultLimitReachedHandler(c)
+}
+
 func addRateLimitHeaders(c *gin.Context, rateLimit config.RateLimit) {
 	c.Header("X-RateLimit-Limit", strconv.Itoa(rateLimit.Requests))
 
@@ -115,17 +161,24 @@ func logRateLimitViolation(c *gin.Context) {
 }
 
 func GetRateLimitForEndpoint(endpoint string, rateLimitConfig *config.EnhancedRateLimitConfig) config.RateLimit {
+	if strings.HasPrefix(endpoint, "/api/admin/assets/") {
+		return rateLimitConfig.Upload
+	}
+	if strings.HasPrefix(endpoint, "/api/assets/") {
+		return rateLimitConfig.Public
+	}
+
 	switch endpoint {
 	case "/api/admin/login":
 		return rateLimitConfig.Login
 	case "/api/admin/refresh":
 		return rateLimitConfig.Refresh
-	case "/api/admin/assets/hero-banner":
-		return rateLimitConfig.Upload
 	case "/api/admin/logout":
 		return rateLimitConfig.Admin
+	case "/api/contact":
+		return rateLimitConfig.Contact
 	default:
-		if endpoint == "/api/hello" || endpoint == "/api/assets/hero-banner" || endpoint == "/api/assets/info" {
+		if endpoint == "/api/hello" {
 			return rateLimitConfig.Public
 		}
 		return rateLimitConfig.API
@@ -5,17 +5,49 @@ import (
 	"time"
 )
 
+// AdminRole decides which permissions an admin's access tokens carry
+type AdminRole string
+
+const (
+	AdminRoleOwner  AdminRole = "owner"
+	AdminRoleEditor AdminRole = "editor"
+	AdminRoleViewer AdminRole = "viewer"
+)
+
+func (r AdminRole) IsValid() bool {
+	switch r {
+	case AdminRoleOwner, AdminRoleEditor, AdminRoleViewer:
+		return true
+	}
+	return false
+}
+
 type Admin struct {
-	ID              int       `json:"id" db:"id"`
-	Username        string    `json:"username" db:"username"`
-	PasswordHash    string    `json:"-" db:"password_hash"`
-	PasswordSalt    *string   `json:"-" db:"password_salt"`
-	HashVersion     int       `json:"-" db:"hash_version"`
-	LastLogin       NullTime  `json:"last_login" db:"last_login"`
-	CurrentToken    *string   `json:"-" db:"current_token"`
-	TokenExpiration NullTime  `json:"-" db:"token_expiration"`
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+	ID                int       `json:"id" db:"id"`
+	Username          string    `json:"username" db:"username"`
+	PasswordHash      string    `json:"-" db:"password_hash"`
+	PasswordSalt      *string   `json:"-" db:"password_salt"`
+	HashVersion       int       `json:"-" db:"hash_version"`
+	LastLogin         NullTime  `json:"last_login" db:"last_login"`
+	IsActive          bool      `json:"is_active" db:"is_active"`
+	DisabledAt        NullTime  `json:"disabled_at" db:"disabled_at"`
+	Role              AdminRole `json:"role" db:"role"`
+	CreatedBy         *int      `json:"created_by,omitempty" db:"created_by"`
+	PasswordChangedAt NullTime  `json:"password_changed_at" db:"password_changed_at"`
+	TOTPSecret        *string   `json:"-" db:"totp_secret"`
+	TOTPEnabledAt     NullTime  `json:"totp_enabled_at" db:"totp_enabled_at"`
+	TOTPLastStep      *int64    `json:"-" db:"totp_last_step"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type PasswordResetToken struct {
+	ID        int       `json:"id" db:"id"`
+	AdminID   int       `json:"admin_id" db:"admin_id"`
+	CreatedBy *int      `json:"created_by,omitempty" db:"created_by"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	UsedAt    NullTime  `json:"used_at" db:"used_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 type LoginAttempt struct {
@@ -25,6 +57,7 @@ type LoginAttempt struct {
 	Success   bool      `json:"success" db:"success"`
 	AttemptAt time.Time `json:"attempt_at" db:"attempt_at"`
 	Details   *string   `json:"details,omitempty" db:"details"`
+	AdminID   *int      `json:"admin_id,omitempty" db:"admin_id"`
 }
 
 type NullTime struct {
@@ -61,9 +94,51 @@ type LoginResponse struct {
 }
 
 type AdminUser struct {
-	ID        int      `json:"id" example:"1"`
-	Username  string   `json:"username" example:"admin"`
-	LastLogin NullTime `json:"last_login"`
+	ID          int       `json:"id" example:"1"`
+	Username    string    `json:"username" example:"admin"`
+	Role        AdminRole `json:"role" example:"owner"`
+	Permissions []string  `json:"permissions" example:"content:read,content:write"`
+	LastLogin   NullTime  `json:"last_login"`
+}
+
+type AdminListResponse struct {
+	Admins []Admin `json:"admins"`
+}
+
+type CreateAdminRequest struct {
+	Username string `json:"username" binding:"required" example:"editor"`
+	// Password is optional; when omitted a temporary password is generated and returned once
+	Password *string `json:"password,omitempty" example:"Sup3r-secret!"`
+	// Role defaults to viewer
+	Role AdminRole `json:"role,omitempty" example:"editor"`
+}
+
+type CreateAdminResponse struct {
+	Admin             Admin  `json:"admin"`
+	TemporaryPassword string `json:"temporary_password,omitempty" example:"q7Lm-2xVr9Kp!cTe4WbN"`
+}
+
+type UpdateRoleRequest struct {
+	Role AdminRole `json:"role" binding:"required" example:"editor"`
+}
+
+type UpdateUsernameRequest struct {
+	Username string `json:"username" binding:"required" example:"new-admin"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"old-password"`
+	NewPassword     string `json:"new_password" binding:"required" example:"N3w-password!"`
+}
+
+type PasswordResetTokenResponse struct {
+	Token     string    `json:"token" example:"Jm9vYmFyLXJlc2V0LXRva2VuLWV4YW1wbGU"`
+	ExpiresAt time.Time `json:"expires_at" example:"2023-12-31T23:59:59Z"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required" example:"Jm9vYmFyLXJlc2V0LXRva2VuLWV4YW1wbGU"`
+	NewPassword string `json:"new_password" binding:"required" example:"N3w-password!"`
 }
 
 type ErrorResponse struct {
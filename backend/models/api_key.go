@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// APIKey is a named credential for machine clients, sent as "Authorization: ApiKey <key>"
+type APIKey struct {
+	ID         int       `json:"id" db:"id"`
+	AdminID    int       `json:"admin_id" db:"admin_id"`
+	Name       string    `json:"name" db:"name"`
+	KeyPrefix  string    `json:"key_prefix" db:"key_prefix"`
+	KeyHash    string    `json:"-" db:"key_hash"`
+	Scopes     []string  `json:"scopes" db:"scopes"`
+	ExpiresAt  NullTime  `json:"expires_at" db:"expires_at"`
+	LastUsedAt NullTime  `json:"last_used_at" db:"last_used_at"`
+	LastUsedIP *string   `json:"last_used_ip,omitempty" db:"last_used_ip"`
+	RevokedAt  NullTime  `json:"-" db:"revoked_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required" example:"CI deploy"`
+	Scopes []string `json:"scopes" binding:"required" example:"content:write,assets:write"`
+	// ExpiresAt is optional; keys without one are valid until revoked
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2026-01-01T00:00:00Z"`
+}
+
+// CreateAPIKeyResponse carries the only copy of the key the server ever returns
+type CreateAPIKeyResponse struct {
+	APIKey APIKey `json:"api_key"`
+	Key    string `json:"key" example:"pwa_3q2-7wAAAAA3q2-7wAAAAA3q2-7wAAAAA3q2-7wA"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
+
+type RevokeAPIKeysResponse struct {
+	Message string `json:"message" example:"API keys revoked successfully"`
+	Revoked int64  `json:"revoked" example:"2"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type AssetSlotInfo struct {
+	Slot         string         `json:"slot" example:"hero-banner"`
+	Description  string         `json:"description" example:"Background image for the home page hero section"`
+	URL          string         `json:"url" example:"/api/assets/hero-banner"`
+	Available    bool           `json:"available" example:"true"`
+	ContentType  *string        `json:"content_type,omitempty" example:"image/jpeg"`
+	Size         *int64         `json:"size,omitempty" example:"245760"`
+	LastModified *time.Time     `json:"last_modified,omitempty"`
+	AllowedTypes []string       `json:"allowed_types" example:"image/jpeg,image/png"`
+	MaxSize      int64          `json:"max_size" example:"10485760"`
+	Variants     []AssetVariant `json:"variants"`
+}
+
+type AssetVariant struct {
+	Width       int    `json:"width" example:"960"`
+	Height      int    `json:"height" example:"540"`
+	Format      string `json:"format" example:"jpeg"`
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	URL         string `json:"url" example:"/api/assets/hero-banner?w=960&format=jpeg"`
+}
+
+type AssetInfoResponse struct {
+	Assets []AssetSlotInfo `json:"assets"`
+}
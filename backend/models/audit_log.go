@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLogEntry records one change made through the admin API. Before and After hold only the
+// fields the change touched.
+type AuditLogEntry struct {
+	ID            int64           `json:"id" db:"id"`
+	ActorID       *int            `json:"actor_id,omitempty" db:"actor_id"`
+	ActorUsername *string         `json:"actor_username,omitempty" db:"actor_username" example:"admin"`
+	APIKeyID      *int            `json:"api_key_id,omitempty" db:"api_key_id"`
+	Action        string          `json:"action" db:"action" example:"project_updated"`
+	TargetType    *string         `json:"target_type,omitempty" db:"target_type" example:"projects"`
+	TargetID      *string         `json:"target_id,omitempty" db:"target_id" example:"3"`
+	Before        json.RawMessage `json:"before,omitempty" db:"before_data" swaggertype:"object"`
+	After         json.RawMessage `json:"after,omitempty" db:"after_data" swaggertype:"object"`
+	IPAddress     string          `json:"ip_address" db:"ip_address" example:"203.0.113.7"`
+	UserAgent     *string         `json:"user_agent,omitempty" db:"user_agent"`
+	RequestID     *string         `json:"request_id,omitempty" db:"request_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter narrows an audit log listing. Nil fields are not filtered on.
+type AuditLogFilter struct {
+	ActorID    *int
+	Action     *string
+	TargetType *string
+	TargetID   *string
+	// IPAddress is a single address or a CIDR range
+	IPAddress *string
+	From      *time.Time
+	To        *time.Time
+}
+
+type AuditLogListResponse struct {
+	Entries    []AuditLogEntry `json:"entries"`
+	Pagination Pagination      `json:"pagination"`
+}
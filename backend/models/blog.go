@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type BlogPostStatus string
+
+const (
+	BlogPostStatusDraft     BlogPostStatus = "draft"
+	BlogPostStatusScheduled BlogPostStatus = "scheduled"
+	BlogPostStatusPublished BlogPostStatus = "published"
+)
+
+func (s BlogPostStatus) IsValid() bool {
+	switch s {
+	case BlogPostStatusDraft, BlogPostStatusScheduled, BlogPostStatusPublished:
+		return true
+	}
+	return false
+}
+
+type TOCEntry struct {
+	Level int    `json:"level" example:"2"`
+	ID    string `json:"id" example:"getting-started"`
+	Text  string `json:"text" example:"Getting started"`
+}
+
+// TableOfContents is stored as JSONB alongside the rendered post body
+type TableOfContents []TOCEntry
+
+func (t TableOfContents) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *TableOfContents) Scan(value interface{}) error {
+	if value == nil {
+		*t = TableOfContents{}
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported table of contents type: %T", value)
+	}
+
+	return json.Unmarshal(data, t)
+}
+
+type BlogPost struct {
+	ID            int             `json:"id" db:"id"`
+	Slug          string          `json:"slug" db:"slug"`
+	Title         string          `json:"title" db:"title"`
+	Excerpt       *string         `json:"excerpt,omitempty" db:"excerpt"`
+	Content       string          `json:"content" db:"content"`
+	ContentHTML   string          `json:"content_html" db:"content_html"`
+	TOC           TableOfContents `json:"toc" db:"toc"`
+	CoverImageURL *string         `json:"cover_image_url,omitempty" db:"cover_image_url"`
+	Status        BlogPostStatus  `json:"status" db:"status"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+	AuthorID      *int            `json:"author_id,omitempty" db:"author_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+type BlogPostRequest struct {
+	Slug          string  `json:"slug" example:"hello-world"`
+	Title         string  `json:"title" binding:"required" example:"Hello World"`
+	Excerpt       *string `json:"excerpt" example:"A short introduction to the blog"`
+	Content       string  `json:"content" binding:"required" example:"# Welcome\n\nThis post is written in **Markdown**."`
+	CoverImageURL *string `json:"cover_image_url" example:"/api/assets/hero-banner"`
+}
+
+type PreviewPostRequest struct {
+	Content string `json:"content" binding:"required" example:"## Heading\n\nSome *Markdown*."`
+}
+
+type PreviewPostResponse struct {
+	ContentHTML string          `json:"content_html" example:"<h2 id=\"heading\">Heading</h2>"`
+	TOC         TableOfContents `json:"toc"`
+}
+
+type SchedulePostRequest struct {
+	PublishAt time.Time `json:"publish_at" binding:"required" example:"2030-01-01T09:00:00Z"`
+}
+
+type BlogPostListResponse struct {
+	Posts      []BlogPost `json:"posts"`
+	Pagination Pagination `json:"pagination"`
+}
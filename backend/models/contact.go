@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type ContactMessage struct {
+	ID        int        `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Email     string     `json:"email" db:"email"`
+	Subject   *string    `json:"subject,omitempty" db:"subject"`
+	Message   string     `json:"message" db:"message"`
+	IPAddress string     `json:"ip_address" db:"ip_address"`
+	UserAgent *string    `json:"user_agent,omitempty" db:"user_agent"`
+	Handled   bool       `json:"handled" db:"handled"`
+	HandledAt *time.Time `json:"handled_at,omitempty" db:"handled_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type ContactRequest struct {
+	Name    string  `json:"name" binding:"required" example:"Jane Doe"`
+	Email   string  `json:"email" binding:"required" example:"jane@example.com"`
+	Subject *string `json:"subject" example:"Project enquiry"`
+	Message string  `json:"message" binding:"required" example:"Hi, I would like to talk about a project."`
+	// Website is a honeypot field that is hidden from humans and must stay empty
+	Website string `json:"website" example:""`
+	// FormToken is the token from GET /contact/token, fetched when the form was rendered
+	FormToken string `json:"form_token" binding:"required" example:"1700000000000.kT3v9fQ2"`
+}
+
+type ContactFormTokenResponse struct {
+	FormToken string `json:"form_token" example:"1700000000000.kT3v9fQ2"`
+}
+
+type ContactMessageListResponse struct {
+	Messages   []ContactMessage `json:"messages"`
+	Pagination Pagination       `json:"pagination"`
+}
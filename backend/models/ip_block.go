@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	// IPBlockScopeAdmin blocks only the admin API
+	IPBlockScopeAdmin = "admin"
+	// IPBlockScopeAll blocks every route
+	IPBlockScopeAll = "all"
+)
+
+// IPBlock is a temporary block on an IP address or range, added by an admin at runtime
+type IPBlock struct {
+	ID        int       `json:"id" db:"id"`
+	Network   string    `json:"network" db:"network" example:"203.0.113.0/24"`
+	Scope     string    `json:"scope" db:"scope" example:"admin"`
+	Reason    *string   `json:"reason,omitempty" db:"reason" example:"Credential stuffing"`
+	CreatedBy *int      `json:"created_by,omitempty" db:"created_by"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateIPBlockRequest struct {
+	// Network is a single IP address or a CIDR range
+	Network string `json:"network" binding:"required" example:"203.0.113.0/24"`
+	// Scope defaults to admin
+	Scope     string    `json:"scope,omitempty" example:"admin"`
+	Reason    string    `json:"reason,omitempty" example:"Credential stuffing"`
+	ExpiresAt time.Time `json:"expires_at" binding:"required" example:"2026-01-01T00:00:00Z"`
+}
+
+type IPBlockListResponse struct {
+	Blocks []IPBlock `json:"blocks"`
+}
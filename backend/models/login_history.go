@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// LoginAttemptFilter narrows a login attempt listing. Nil fields are not filtered on.
+type LoginAttemptFilter struct {
+	Success *bool
+	// IPAddress is a single address or a CIDR range
+	IPAddress *string
+	From      *time.Time
+	To        *time.Time
+}
+
+type LoginAttemptListResponse struct {
+	Attempts   []LoginAttempt `json:"attempts"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+type RecentLoginsResponse struct {
+	Logins []LoginAttempt `json:"logins"`
+}
+
+type HourlyFailureCount struct {
+	Hour     time.Time `json:"hour"`
+	Failures int       `json:"failures" example:"12"`
+}
+
+type IPFailureCount struct {
+	IPAddress     string    `json:"ip_address" example:"203.0.113.7"`
+	Failures      int       `json:"failures" example:"40"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+}
+
+type UserAgentCount struct {
+	UserAgent string `json:"user_agent" example:"Mozilla/5.0"`
+	Attempts  int    `json:"attempts" example:"25"`
+	Failures  int    `json:"failures" example:"3"`
+}
+
+// LoginAttemptStats summarises login attempts between From and To
+type LoginAttemptStats struct {
+	From               time.Time            `json:"from"`
+	To                 time.Time            `json:"to"`
+	TotalAttempts      int                  `json:"total_attempts" example:"130"`
+	FailedAttempts     int                  `json:"failed_attempts" example:"52"`
+	DistinctIPs        int                  `json:"distinct_ips" example:"9"`
+	DistinctUserAgents int                  `json:"distinct_user_agents" example:"6"`
+	FailuresPerHour    []HourlyFailureCount `json:"failures_per_hour"`
+	TopFailedIPs       []IPFailureCount     `json:"top_failed_ips"`
+	UserAgents         []UserAgentCount     `json:"user_agents"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	LoginLockoutScopeUsername = "username"
+	LoginLockoutScopeIP       = "ip"
+)
+
+// LoginLockout tracks consecutive failed logins for one username or client IP
+type LoginLockout struct {
+	Scope          string    `json:"scope" db:"scope" example:"username"`
+	Subject        string    `json:"subject" db:"subject" example:"admin"`
+	FailedAttempts int       `json:"failed_attempts" db:"failed_attempts" example:"5"`
+	LastFailedAt   time.Time `json:"last_failed_at" db:"last_failed_at"`
+	NextAttemptAt  NullTime  `json:"next_attempt_at" db:"next_attempt_at"`
+	LockedUntil    NullTime  `json:"locked_until" db:"locked_until"`
+}
+
+type LoginLockoutListResponse struct {
+	Lockouts []LoginLockout `json:"lockouts"`
+}
+
+type UnlockLoginRequest struct {
+	Scope   string `json:"scope" binding:"required" example:"username"`
+	Subject string `json:"subject" binding:"required" example:"admin"`
+}
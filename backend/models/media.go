@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Media struct {
+	ID          int       `json:"id" db:"id"`
+	ObjectKey   string    `json:"object_key" db:"object_key"`
+	ContentHash string    `json:"content_hash" db:"content_hash"`
+	URL         string    `json:"url"`
+	Filename    string    `json:"filename" db:"filename"`
+	MimeType    string    `json:"mime_type" db:"mime_type"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	Width       *int      `json:"width,omitempty" db:"width"`
+	Height      *int      `json:"height,omitempty" db:"height"`
+	AltText     *string   `json:"alt_text,omitempty" db:"alt_text"`
+	UploadedBy  *int      `json:"uploaded_by,omitempty" db:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type MediaListResponse struct {
+	Media      []Media    `json:"media"`
+	Pagination Pagination `json:"pagination"`
+}
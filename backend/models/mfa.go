@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type MFAStatusResponse struct {
+	TOTPEnabled            bool     `json:"totp_enabled" example:"true"`
+	TOTPEnabledAt          NullTime `json:"totp_enabled_at"`
+	RecoveryCodesRemaining int      `json:"recovery_codes_remaining" example:"10"`
+}
+
+type TOTPSetupResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Portfolio%20Admin:admin?algorithm=SHA1&digits=6&issuer=Portfolio+Admin&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+type RecoveryCodesResponse struct {
+	Message       string   `json:"message" example:"Two-factor authentication enabled"`
+	RecoveryCodes []string `json:"recovery_codes" example:"k7m2p-x9q4r,b3n8t-w6c5h"`
+}
+
+// MFAChallengeResponse is returned by login instead of LoginResponse when a second factor is required
+type MFAChallengeResponse struct {
+	MFARequired bool      `json:"mfa_required" example:"true"`
+	MFAToken    string    `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt   time.Time `json:"expires_at" example:"2023-12-31T23:59:59Z"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
@@ -0,0 +1,22 @@
+package models
+
+type Pagination struct {
+	Page       int `json:"page" example:"1"`
+	PageSize   int `json:"page_size" example:"10"`
+	Total      int `json:"total" example:"42"`
+	TotalPages int `json:"total_pages" example:"5"`
+}
+
+func NewPagination(page, pageSize, total int) Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+
+	return Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type Project struct {
+	ID            int       `json:"id" db:"id"`
+	Slug          string    `json:"slug" db:"slug"`
+	Title         string    `json:"title" db:"title"`
+	Summary       *string   `json:"summary,omitempty" db:"summary"`
+	Description   string    `json:"description" db:"description"`
+	ImageURL      *string   `json:"image_url,omitempty" db:"image_url"`
+	ImageAlt      *string   `json:"image_alt,omitempty" db:"image_alt"`
+	TechStack     []string  `json:"tech_stack" db:"tech_stack"`
+	RepositoryURL *string   `json:"repository_url,omitempty" db:"repository_url"`
+	LiveURL       *string   `json:"live_url,omitempty" db:"live_url"`
+	Featured      bool      `json:"featured" db:"featured"`
+	SortOrder     int       `json:"sort_order" db:"sort_order"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ProjectRequest struct {
+	Slug          string   `json:"slug" example:"portfolio-webapplication"`
+	Title         string   `json:"title" binding:"required" example:"Portfolio Web Application"`
+	Summary       *string  `json:"summary" example:"Personal portfolio built with Next.js and Go"`
+	Description   string   `json:"description" binding:"required" example:"A full-stack portfolio site."`
+	ImageURL      *string  `json:"image_url" example:"/api/assets/hero-banner"`
+	ImageAlt      *string  `json:"image_alt" example:"Screenshot of the home page"`
+	TechStack     []string `json:"tech_stack" example:"go,typescript"`
+	RepositoryURL *string  `json:"repository_url" example:"https://github.com/Wildcard209/portfolio-webapplication"`
+	LiveURL       *string  `json:"live_url" example:"https://example.com"`
+	Featured      bool     `json:"featured" example:"true"`
+	SortOrder     int      `json:"sort_order" example:"0"`
+}
+
+type ProjectListResponse struct {
+	Projects []Project `json:"projects"`
+	Total    int       `json:"total" example:"4"`
+}
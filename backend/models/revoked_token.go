@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RevokedToken is an access token that must be rejected until it expires
+type RevokedToken struct {
+	TokenID   string    `json:"jti" db:"jti"`
+	AdminID   *int      `json:"admin_id,omitempty" db:"admin_id"`
+	Reason    string    `json:"reason" db:"reason"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at" db:"revoked_at"`
+}
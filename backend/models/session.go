@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID                string    `json:"id" db:"id"`
+	AdminID           int       `json:"admin_id" db:"admin_id"`
+	RefreshTokenHash  string    `json:"-" db:"refresh_token_hash"`
+	PreviousTokenHash *string   `json:"-" db:"previous_token_hash"`
+	RotatedAt         NullTime  `json:"-" db:"rotated_at"`
+	AccessTokenID     *string   `json:"-" db:"access_token_id"`
+	AccessExpiresAt   NullTime  `json:"-" db:"access_expires_at"`
+	UserAgent         *string   `json:"user_agent,omitempty" db:"user_agent"`
+	IPAddress         *string   `json:"ip_address,omitempty" db:"ip_address"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	LastUsedAt        time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt         time.Time `json:"expires_at" db:"expires_at"`
+	RevokedAt         NullTime  `json:"-" db:"revoked_at"`
+	// Current marks the session making the request
+	Current bool `json:"current" db:"-"`
+}
+
+type SessionListResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+type RevokeSessionsResponse struct {
+	Message string `json:"message" example:"Other sessions revoked successfully"`
+	Revoked int64  `json:"revoked" example:"2"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebAuthnCredential struct {
+	ID              int       `json:"id" db:"id"`
+	AdminID         int       `json:"admin_id" db:"admin_id"`
+	CredentialID    []byte    `json:"-" db:"credential_id"`
+	UserHandle      []byte    `json:"-" db:"user_handle"`
+	PublicKey       []byte    `json:"-" db:"public_key"`
+	AttestationType string    `json:"-" db:"attestation_type"`
+	AAGUID          []byte    `json:"-" db:"aaguid"`
+	SignCount       int64     `json:"-" db:"sign_count"`
+	Transports      []string  `json:"transports" db:"transports"`
+	BackupEligible  bool      `json:"backup_eligible" db:"backup_eligible"`
+	BackupState     bool      `json:"backup_state" db:"backup_state"`
+	Name            string    `json:"name" db:"name"`
+	LastUsedAt      NullTime  `json:"last_used_at" db:"last_used_at"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+type WebAuthnCredentialListResponse struct {
+	Credentials []WebAuthnCredential `json:"credentials"`
+}
+
+// WebAuthnOptionsResponse carries the options for navigator.credentials.create() or .get()
+// and the ceremony ID to send back with the authenticator's response
+type WebAuthnOptionsResponse struct {
+	CeremonyID string      `json:"ceremony_id" example:"3q2-7wAAAAA3q2-7wAAAA"`
+	Options    interface{} `json:"options" swaggertype:"object"`
+}
+
+type WebAuthnRegistrationRequest struct {
+	CeremonyID string          `json:"ceremony_id" binding:"required" example:"3q2-7wAAAAA3q2-7wAAAA"`
+	Name       string          `json:"name" binding:"required" example:"MacBook Touch ID"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+}
+
+type WebAuthnLoginRequest struct {
+	CeremonyID string          `json:"ceremony_id" binding:"required" example:"3q2-7wAAAAA3q2-7wAAAA"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+}
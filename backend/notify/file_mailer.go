@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// FileMailer writes every message into a Maildir so notifications can be inspected
+// locally without a mail server
+type FileMailer struct {
+	dir      string
+	hostname string
+	counter  atomic.Uint64
+}
+
+func NewFileMailer(dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file mail driver requires MAIL_FILE_DIR to be set")
+	}
+
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create maildir %s: %w", sub, err)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "localhost"
+	}
+
+	return &FileMailer{
+		dir:      dir,
+		hostname: hostname,
+	}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, message Message) error {
+	now := time.Now()
+
+	data, err := message.encode(now)
+	if err != nil {
+		return err
+	}
+
+	// Maildir delivery: write into tmp/ and atomically rename into new/
+	name := fmt.Sprintf("%d.M%dP%dQ%d.%s", now.Unix(), now.Nanosecond()/1000, os.Getpid(), m.counter.Add(1), m.hostname)
+	tmpPath := filepath.Join(m.dir, "tmp", name)
+	newPath := filepath.Join(m.dir, "new", name)
+
+	if err := os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return fmt.Errorf("failed to write message file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, newPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to deliver message file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+)
+
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers a single message; implementations must be safe for concurrent use
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+const (
+	DriverNone = "none"
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+)
+
+func NewMailer(cfg *config.NotificationConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP mail driver requires SMTP_HOST to be set")
+		}
+		return NewSMTPMailer(SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			Security: cfg.SMTPSecurity,
+			Timeout:  cfg.SMTPTimeout,
+		}), nil
+	case DriverFile:
+		return NewFileMailer(cfg.FileDir)
+	case DriverNone, "":
+		return NoopMailer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
+
+// NoopMailer discards every message and is used when notifications are disabled
+type NoopMailer struct{}
+
+func (NoopMailer) Send(ctx context.Context, message Message) error {
+	return nil
+}
+
+// encode renders the message as an RFC 5322 document with a quoted-printable body
+func (m Message) encode(now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "localhost"
+	}
+
+	headers := []struct {
+		key   string
+		value string
+	}{
+		{"From", stripHeaderBreaks(m.From)},
+		{"To", stripHeaderBreaks(strings.Join(m.To, ", "))},
+		{"Subject", mime.QEncoding.Encode("utf-8", stripHeaderBreaks(m.Subject))},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%d.%d@%s>", now.UnixNano(), os.Getpid(), hostname)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+
+	for _, header := range headers {
+		buf.WriteString(header.key + ": " + header.value + "\r\n")
+	}
+	buf.WriteString("\r\n")
+
+	writer := quotedprintable.NewWriter(&buf)
+	if _, err := writer.Write([]byte(m.Body)); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func stripHeaderBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+// Notifier turns application events into emails for the site admin. A nil *Notifier
+// is valid and silently drops every notification.
+type Notifier struct {
+	mailer       Mailer
+	from         string
+	adminAddress string
+	sendTimeout  time.Duration
+	logger       *utils.SecurityLogger
+}
+
+func NewNotifier(mailer Mailer, from, adminAddress string) *Notifier {
+	return &Notifier{
+		mailer:       mailer,
+		from:         from,
+		adminAddress: adminAddress,
+		sendTimeout:  30 * time.Second,
+		logger:       utils.NewSecurityLogger(),
+	}
+}
+
+// NewNotifierFromConfig builds a Notifier for the configured driver, returning nil
+// when notifications are disabled or no admin address is configured
+func NewNotifierFromConfig(cfg *config.NotificationConfig) (*Notifier, error) {
+	if cfg == nil || cfg.Driver == DriverNone || cfg.Driver == "" || cfg.AdminAddress == "" {
+		return nil, nil
+	}
+
+	mailer, err := NewMailer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNotifier(mailer, cfg.From, cfg.AdminAddress), nil
+}
+
+func (n *Notifier) ContactMessageReceived(message *models.ContactMessage) {
+	subject := "New contact message from " + message.Name
+	if message.Subject != nil && *message.Subject != "" {
+		subject = "Contact: " + *message.Subject
+	}
+
+	body := fmt.Sprintf(
+		"A new message was submitted through the contact form.\n\n"+
+			"Name: %s\nEmail: %s\nReceived: %s\n\n%s\n",
+		message.Name,
+		message.Email,
+		message.CreatedAt.UTC().Format(time.RFC1123),
+		message.Message,
+	)
+
+	n.sendToAdmin(subject, body)
+}
+
+func (n *Notifier) AccountLockedOut(username, clientIP string, failedAttempts int, lockoutDuration time.Duration) {
+	body := fmt.Sprintf(
+		"Login for the admin area has been locked after %d failed attempts.\n\n"+
+			"Username tried: %s\nIP address: %s\nLockout duration: %s\nTime: %s\n\n"+
+			"If this was not you, review the login history and consider changing your password.\n",
+		failedAttempts,
+		username,
+		clientIP,
+		lockoutDuration,
+		time.Now().UTC().Format(time.RFC1123),
+	)
+
+	n.sendToAdmin("Admin login locked out after failed attempts", body)
+}
+
+func (n *Notifier) NewLoginLocation(username, clientIP, userAgent string) {
+	body := fmt.Sprintf(
+		"The admin account %q signed in from an IP address that has not been seen before.\n\n"+
+			"IP address: %s\nUser agent: %s\nTime: %s\n\n"+
+			"If this was not you, log in and change your password immediately.\n",
+		username,
+		clientIP,
+		userAgent,
+		time.Now().UTC().Format(time.RFC1123),
+	)
+
+	n.sendToAdmin("New admin login from "+clientIP, body)
+}
+
+// sendToAdmin delivers in the background so request handlers never wait on the mail server
+func (n *Notifier) sendToAdmin(subject, body string) {
+	if n == nil || n.mailer == nil {
+		return
+	}
+
+	message := Message{
+		From:    n.from,
+		To:      []string{n.adminAddress},
+		Subject: strings.TrimSpace(subject),
+		Body:    body,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), n.sendTimeout)
+		defer cancel()
+
+		if err := n.mailer.Send(ctx, message); err != nil {
+			n.logger.LogSecureError("notification delivery", err)
+		}
+	}()
+}
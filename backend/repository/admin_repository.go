@@ -3,7 +3,6 @@ package repository
 import (
 	"database/sql"
 	"fmt"
-	"time"
 
 	"github.com/Wildcard209/portfolio-webapplication/database"
 	"github.com/Wildcard209/portfolio-webapplication/models"
@@ -32,20 +31,7 @@ func (r *AdminRepository) GetAdminByUsername(username string) (*models.Admin, er
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	admin := &models.Admin{}
-	err = r.db.QueryRow(query, username).Scan(
-		&admin.ID,
-		&admin.Username,
-		&admin.PasswordHash,
-		&admin.PasswordSalt,
-		&admin.HashVersion,
-		&admin.LastLogin,
-		&admin.CurrentToken,
-		&admin.TokenExpiration,
-		&admin.CreatedAt,
-		&admin.UpdatedAt,
-	)
-
+	admin, err := scanAdmin(r.db.QueryRow(query, username))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -62,20 +48,7 @@ func (r *AdminRepository) GetAdminByID(id int) (*models.Admin, error) {
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	admin := &models.Admin{}
-	err = r.db.QueryRow(query, id).Scan(
-		&admin.ID,
-		&admin.Username,
-		&admin.PasswordHash,
-		&admin.PasswordSalt,
-		&admin.HashVersion,
-		&admin.LastLogin,
-		&admin.CurrentToken,
-		&admin.TokenExpiration,
-		&admin.CreatedAt,
-		&admin.UpdatedAt,
-	)
-
+	admin, err := scanAdmin(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -86,80 +59,101 @@ func (r *AdminRepository) GetAdminByID(id int) (*models.Admin, error) {
 	return admin, nil
 }
 
+// CreateAdmin creates an owner account, as used to bootstrap the system
 func (r *AdminRepository) CreateAdmin(username, passwordHash, passwordSalt string) (*models.Admin, error) {
+	return r.createAdmin(username, passwordHash, passwordSalt, 2, models.AdminRoleOwner, nil)
+}
+
+func (r *AdminRepository) CreateAdminWithHashVersion(username, passwordHash, passwordSalt string, hashVersion int) (*models.Admin, error) {
+	return r.createAdmin(username, passwordHash, passwordSalt, hashVersion, models.AdminRoleOwner, nil)
+}
+
+// CreateAdminBy creates a bcrypt-hashed admin on behalf of an existing admin
+func (r *AdminRepository) CreateAdminBy(username, passwordHash string, role models.AdminRole, createdBy int) (*models.Admin, error) {
+	return r.createAdmin(username, passwordHash, "", 2, role, &createdBy)
+}
+
+func (r *AdminRepository) createAdmin(username, passwordHash, passwordSalt string, hashVersion int, role models.AdminRole, createdBy *int) (*models.Admin, error) {
 	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.CreateAdmin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	admin := &models.Admin{}
-	hashVersion := 2
 	var saltPtr *string
 	if passwordSalt != "" {
 		saltPtr = &passwordSalt
 	}
 
-	err = r.db.QueryRow(query, username, passwordHash, saltPtr, hashVersion).Scan(
-		&admin.ID,
-		&admin.Username,
-		&admin.PasswordHash,
-		&admin.PasswordSalt,
-		&admin.HashVersion,
-		&admin.LastLogin,
-		&admin.CurrentToken,
-		&admin.TokenExpiration,
-		&admin.CreatedAt,
-		&admin.UpdatedAt,
-	)
-
+	admin, err := scanAdmin(r.db.QueryRow(query, username, passwordHash, saltPtr, hashVersion, role, createdBy))
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateUsername
+		}
 		return nil, fmt.Errorf("failed to create admin: %w", err)
 	}
 
 	return admin, nil
 }
 
-func (r *AdminRepository) CreateAdminWithHashVersion(username, passwordHash, passwordSalt string, hashVersion int) (*models.Admin, error) {
-	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.CreateAdmin)
+// ListAdmins returns every admin account, oldest first
+func (r *AdminRepository) ListAdmins() ([]models.Admin, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.ListAdmins)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	admin := &models.Admin{}
-	var saltPtr *string
-	if passwordSalt != "" {
-		saltPtr = &passwordSalt
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list admins: %w", err)
 	}
+	defer rows.Close()
 
-	err = r.db.QueryRow(query, username, passwordHash, saltPtr, hashVersion).Scan(
-		&admin.ID,
-		&admin.Username,
-		&admin.PasswordHash,
-		&admin.PasswordSalt,
-		&admin.HashVersion,
-		&admin.LastLogin,
-		&admin.CurrentToken,
-		&admin.TokenExpiration,
-		&admin.CreatedAt,
-		&admin.UpdatedAt,
-	)
+	admins := []models.Admin{}
+	for rows.Next() {
+		admin, err := scanAdmin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan admin: %w", err)
+		}
+		admins = append(admins, *admin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate admins: %w", err)
+	}
 
+	return admins, nil
+}
+
+func (r *AdminRepository) UpdateAdminUsername(id int, username string) (*models.Admin, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.UpdateAdminUsername)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create admin: %w", err)
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	admin, err := scanAdmin(r.db.QueryRow(query, username, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateUsername
+		}
+		return nil, fmt.Errorf("failed to update admin username: %w", err)
 	}
 
 	return admin, nil
 }
 
-func (r *AdminRepository) UpdateAdminToken(id int, token string, expiration time.Time) error {
-	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.UpdateAdminToken)
+// UpdateAdminPassword stores a new bcrypt hash, upgrading legacy accounts
+func (r *AdminRepository) UpdateAdminPassword(id int, passwordHash string) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.UpdateAdminPassword)
 	if err != nil {
 		return fmt.Errorf("failed to get query: %w", err)
 	}
 
-	result, err := r.db.Exec(query, token, expiration, id)
+	result, err := r.db.Exec(query, passwordHash, id)
 	if err != nil {
-		return fmt.Errorf("failed to update admin token: %w", err)
+		return fmt.Errorf("failed to update admin password: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -174,50 +168,208 @@ func (r *AdminRepository) UpdateAdminToken(id int, token string, expiration time
 	return nil
 }
 
-func (r *AdminRepository) InvalidateAdminToken(id int) error {
-	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.InvalidateAdminToken)
+func (r *AdminRepository) EnableAdmin(id int) (*models.Admin, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.SetAdminActive)
 	if err != nil {
-		return fmt.Errorf("failed to get query: %w", err)
+		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	_, err = r.db.Exec(query, id)
+	admin, err := scanAdmin(r.db.QueryRow(query, true, id))
 	if err != nil {
-		return fmt.Errorf("failed to invalidate admin token: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to enable admin: %w", err)
 	}
 
-	return nil
+	return admin, nil
 }
 
-func (r *AdminRepository) GetAdminByToken(token string) (*models.Admin, error) {
-	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.GetAdminByToken)
+// DisableAdmin deactivates an admin. It returns
+// ErrLastActiveOwner rather than leave the system without an active owner.
+func (r *AdminRepository) DisableAdmin(id int) (*models.Admin, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.SetAdminActive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
-	admin := &models.Admin{}
-	err = r.db.QueryRow(query, token).Scan(
-		&admin.ID,
-		&admin.Username,
-		&admin.PasswordHash,
-		&admin.PasswordSalt,
-		&admin.HashVersion,
-		&admin.LastLogin,
-		&admin.CurrentToken,
-		&admin.TokenExpiration,
-		&admin.CreatedAt,
-		&admin.UpdatedAt,
-	)
+	var admin *models.Admin
+	err = r.withActiveOwnerGuard(id, func(tx *sql.Tx) error {
+		admin, err = scanAdmin(tx.QueryRow(query, false, id))
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err == ErrLastActiveOwner {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to disable admin: %w", err)
+	}
+
+	return admin, nil
+}
+
+// DeleteAdmin permanently removes an admin. It returns ErrLastActiveOwner rather
+// than leave the system without an active owner.
+func (r *AdminRepository) DeleteAdmin(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.DeleteAdmin)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var rowsAffected int64
+	err = r.withActiveOwnerGuard(id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, id)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		if err == ErrLastActiveOwner {
+			return false, err
+		}
+		return false, fmt.Errorf("failed to delete admin: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// withActiveOwnerGuard runs fn in a transaction that holds a lock on every active
+// owner row, so two concurrent requests cannot each remove one of the last two owners
+func (r *AdminRepository) withActiveOwnerGuard(id int, fn func(tx *sql.Tx) error) error {
+	lockQuery, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.LockActiveOwners)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
+	rows, err := tx.Query(lockQuery)
+	if err != nil {
+		return fmt.Errorf("failed to lock active owners: %w", err)
+	}
+
+	activeCount := 0
+	targetActive := false
+	for rows.Next() {
+		var activeID int
+		if err := rows.Scan(&activeID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan active owner: %w", err)
+		}
+		activeCount++
+		if activeID == id {
+			targetActive = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate active owners: %w", err)
+	}
+
+	if targetActive && activeCount == 1 {
+		return ErrLastActiveOwner
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateAdminRole changes an admin's role. Demoting the last active owner returns
+// ErrLastActiveOwner, and nil is returned when the admin does not exist.
+func (r *AdminRepository) UpdateAdminRole(id int, role models.AdminRole) (*models.Admin, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.UpdateAdminRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var admin *models.Admin
+	if role == models.AdminRoleOwner {
+		admin, err = scanAdmin(r.db.QueryRow(query, role, id))
+	} else {
+		err = r.withActiveOwnerGuard(id, func(tx *sql.Tx) error {
+			admin, err = scanAdmin(tx.QueryRow(query, role, id))
+			return err
+		})
+	}
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to get admin by token: %w", err)
+		if err == ErrLastActiveOwner {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update admin role: %w", err)
 	}
 
 	return admin, nil
 }
 
+func (r *AdminRepository) RecordLogin(id int) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.RecordAdminLogin)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to record admin login: %w", err)
+	}
+
+	return nil
+}
+
+// SetTOTPSecret stores a secret for an enrolment in progress. It reports false when the
+// admin does not exist or already has TOTP enabled.
+func (r *AdminRepository) SetTOTPSecret(id int, secret string) (bool, error) {
+	return r.updateTOTP(database.QueryKeys.Admin.SetAdminTOTPSecret, "set TOTP secret", id, secret)
+}
+
+// EnableTOTP activates a pending TOTP secret once its first code has been verified
+func (r *AdminRepository) EnableTOTP(id int, step int64) (bool, error) {
+	return r.updateTOTP(database.QueryKeys.Admin.EnableAdminTOTP, "enable TOTP", id, step)
+}
+
+func (r *AdminRepository) DisableTOTP(id int) error {
+	_, err := r.updateTOTP(database.QueryKeys.Admin.DisableAdminTOTP, "disable TOTP", id)
+	return err
+}
+
+// RecordTOTPStep marks a TOTP time step as used, reporting false if it (or a later one) already was
+func (r *AdminRepository) RecordTOTPStep(id int, step int64) (bool, error) {
+	return r.updateTOTP(database.QueryKeys.Admin.RecordAdminTOTPStep, "record TOTP step", id, step)
+}
+
+func (r *AdminRepository) updateTOTP(queryKey, action string, args ...interface{}) (bool, error) {
+	query, err := r.queryLoader.GetQuery(queryKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (r *AdminRepository) CountAdmins() (int, error) {
 	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.CountAdmins)
 	if err != nil {
@@ -232,16 +384,29 @@ func (r *AdminRepository) CountAdmins() (int, error) {
 	return count, nil
 }
 
-func (r *AdminRepository) CleanupExpiredTokens() error {
-	query, err := r.queryLoader.GetQuery(database.QueryKeys.Admin.CleanupExpiredTokens)
-	if err != nil {
-		return fmt.Errorf("failed to get query: %w", err)
-	}
-
-	_, err = r.db.Exec(query)
+func scanAdmin(row rowScanner) (*models.Admin, error) {
+	admin := &models.Admin{}
+	err := row.Scan(
+		&admin.ID,
+		&admin.Username,
+		&admin.PasswordHash,
+		&admin.PasswordSalt,
+		&admin.HashVersion,
+		&admin.LastLogin,
+		&admin.IsActive,
+		&admin.DisabledAt,
+		&admin.Role,
+		&admin.CreatedBy,
+		&admin.PasswordChangedAt,
+		&admin.TOTPSecret,
+		&admin.TOTPEnabledAt,
+		&admin.TOTPLastStep,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to cleanup expired tokens: %w", err)
+		return nil, err
 	}
 
-	return nil
+	return admin, nil
 }
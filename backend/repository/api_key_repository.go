@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type APIKeyRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+	typeMap     *pgtype.Map
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &APIKeyRepository{
+		db:          db,
+		queryLoader: queryLoader,
+		typeMap:     pgtype.NewMap(),
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(key *models.APIKey) (*models.APIKey, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.CreateAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var expiresAt *time.Time
+	if key.ExpiresAt.Valid {
+		expiresAt = &key.ExpiresAt.Time
+	}
+
+	created, err := r.scanAPIKey(r.db.QueryRow(query,
+		key.AdminID,
+		key.Name,
+		key.KeyPrefix,
+		key.KeyHash,
+		key.Scopes,
+		expiresAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetActiveAPIKeyByHash returns the key with the given hash if it is neither revoked nor expired, or nil
+func (r *APIKeyRepository) GetActiveAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.GetActiveAPIKeyByHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	key, err := r.scanAPIKey(r.db.QueryRow(query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys returns an admin's unrevoked keys, newest first. Expired keys are included so
+// admins can see which ones need replacing.
+func (r *APIKeyRepository) ListAPIKeys(adminID int) ([]models.APIKey, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.ListAPIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(id int, ipAddress string) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.TouchAPIKey)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, id, nullableString(ipAddress))
+	if err != nil {
+		return fmt.Errorf("failed to touch API key: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAPIKey revokes one of an admin's keys. It returns nil when no such unrevoked key exists.
+func (r *APIKeyRepository) RevokeAPIKey(id, adminID int) (*models.APIKey, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.RevokeAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	key, err := r.scanAPIKey(r.db.QueryRow(query, id, adminID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// RevokeAllAPIKeys revokes every key of an admin and returns how many were revoked
+func (r *APIKeyRepository) RevokeAllAPIKeys(adminID int) (int64, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.RevokeAllAPIKeys)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, adminID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke API keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// CleanupAPIKeys deletes keys that were revoked or expired before the cutoff
+func (r *APIKeyRepository) CleanupAPIKeys(cutoff time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.APIKey.CleanupAPIKeys)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup API keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.AdminID,
+		&key.Name,
+		&key.KeyPrefix,
+		&key.KeyHash,
+		r.typeMap.SQLScanner(&key.Scopes),
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.LastUsedIP,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+
+	return key, nil
+}
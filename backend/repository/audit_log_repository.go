@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type AuditLogRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &AuditLogRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *AuditLogRepository) CreateAuditEntry(entry *models.AuditLogEntry) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.AuditLog.CreateAuditEntry)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query,
+		entry.ActorID,
+		entry.ActorUsername,
+		entry.APIKeyID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.IPAddress,
+		entry.UserAgent,
+		entry.RequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEntries returns a page of entries matching the filter, newest first, and the total
+// number that match
+func (r *AuditLogRepository) ListAuditEntries(filter models.AuditLogFilter, limit, offset int) ([]models.AuditLogEntry, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.AuditLog.ListAuditEntries)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.AuditLog.CountAuditEntries)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	args := []interface{}{filter.ActorID, filter.Action, filter.TargetType, filter.TargetID, filter.IPAddress, filter.From, filter.To}
+
+	var total int
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditLogEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit log entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate audit log entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func (r *AuditLogRepository) CleanupAuditLog(olderThan time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.AuditLog.CleanupAuditLog)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, olderThan)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup audit log: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected > 0 {
+		fmt.Printf("Cleaned up %d old audit log entries\n", rowsAffected)
+	}
+
+	return nil
+}
+
+func scanAuditEntry(row rowScanner) (*models.AuditLogEntry, error) {
+	var entry models.AuditLogEntry
+	var before, after []byte
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.ActorID,
+		&entry.ActorUsername,
+		&entry.APIKeyID,
+		&entry.Action,
+		&entry.TargetType,
+		&entry.TargetID,
+		&before,
+		&after,
+		&entry.IPAddress,
+		&entry.UserAgent,
+		&entry.RequestID,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Before = before
+	entry.After = after
+
+	return &entry, nil
+}
+
+// nullableJSON passes empty JSON as NULL, so JSONB columns are not given an empty string
+func nullableJSON(value []byte) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	return nullableString(string(value))
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+func TestListAuditEntriesFiltersByIP(t *testing.T) {
+	repo := NewAuditLogRepository(dbtest.Open(t))
+
+	// A unique action keeps entries from other runs out of the results
+	action := dbtest.UniqueName("test_ip_filter_")
+	addresses := []string{"198.51.100.7", "198.51.100.200", "203.0.113.7"}
+	for _, address := range addresses {
+		if err := repo.CreateAuditEntry(&models.AuditLogEntry{Action: action, IPAddress: address}); err != nil {
+			t.Fatalf("failed to create audit entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"198.51.100.0/24", []string{"198.51.100.7", "198.51.100.200"}},
+		{"198.51.100.7", []string{"198.51.100.7"}},
+		{"198.51.100.7/32", []string{"198.51.100.7"}},
+		{"192.0.2.0/24", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filter := models.AuditLogFilter{Action: &action, IPAddress: &tt.filter}
+
+			entries, total, err := repo.ListAuditEntries(filter, 10, 0)
+			if err != nil {
+				t.Fatalf("failed to list audit entries: %v", err)
+			}
+
+			if total != len(tt.want) || len(entries) != len(tt.want) {
+				t.Fatalf("got %d entries (total %d), want %d", len(entries), total, len(tt.want))
+			}
+
+			// INET values may be read back with a prefix length, e.g. 198.51.100.7/32
+			for _, entry := range entries {
+				address, _, _ := strings.Cut(entry.IPAddress, "/")
+				if !slices.Contains(tt.want, address) {
+					t.Errorf("unexpected entry from %s", entry.IPAddress)
+				}
+			}
+		})
+	}
+}
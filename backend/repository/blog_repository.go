@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type BlogRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewBlogRepository(db *sql.DB) *BlogRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &BlogRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *BlogRepository) ListPublishedPosts(limit, offset int) ([]models.BlogPost, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.ListPublishedPosts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.CountPublishedPosts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var total int
+	if err := r.db.QueryRow(countQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count published posts: %w", err)
+	}
+
+	posts, err := r.queryPosts(query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list published posts: %w", err)
+	}
+
+	return posts, total, nil
+}
+
+func (r *BlogRepository) ListPosts(status string, limit, offset int) ([]models.BlogPost, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.ListPosts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.CountPosts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var total int
+	if err := r.db.QueryRow(countQuery, status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	posts, err := r.queryPosts(query, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	return posts, total, nil
+}
+
+func (r *BlogRepository) GetPublishedPostBySlug(slug string) (*models.BlogPost, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.GetPublishedPostBySlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	post, err := scanBlogPost(r.db.QueryRow(query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get post by slug: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *BlogRepository) GetPostByID(id int) (*models.BlogPost, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.GetPostByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	post, err := scanBlogPost(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get post by ID: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *BlogRepository) CreatePost(post *models.BlogPost) (*models.BlogPost, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.CreatePost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	created, err := scanBlogPost(r.db.QueryRow(query,
+		post.Slug,
+		post.Title,
+		post.Excerpt,
+		post.Content,
+		post.ContentHTML,
+		post.TOC,
+		post.CoverImageURL,
+		post.AuthorID,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *BlogRepository) UpdatePost(id int, post *models.BlogPost) (*models.BlogPost, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.UpdatePost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	updated, err := scanBlogPost(r.db.QueryRow(query,
+		post.Slug,
+		post.Title,
+		post.Excerpt,
+		post.Content,
+		post.ContentHTML,
+		post.TOC,
+		post.CoverImageURL,
+		id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *BlogRepository) UpdatePostStatus(id int, status models.BlogPostStatus, publishedAt *time.Time) (*models.BlogPost, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.UpdatePostStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	updated, err := scanBlogPost(r.db.QueryRow(query, string(status), publishedAt, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update post status: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *BlogRepository) DeletePost(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Blog.DeletePost)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete post: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *BlogRepository) queryPosts(query string, args ...interface{}) ([]models.BlogPost, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []models.BlogPost{}
+	for rows.Next() {
+		post, err := scanBlogPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, *post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+func scanBlogPost(row rowScanner) (*models.BlogPost, error) {
+	post := &models.BlogPost{}
+	err := row.Scan(
+		&post.ID,
+		&post.Slug,
+		&post.Title,
+		&post.Excerpt,
+		&post.Content,
+		&post.ContentHTML,
+		&post.TOC,
+		&post.CoverImageURL,
+		&post.Status,
+		&post.PublishedAt,
+		&post.AuthorID,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
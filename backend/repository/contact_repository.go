@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type ContactRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewContactRepository(db *sql.DB) *ContactRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &ContactRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *ContactRepository) CreateMessage(message *models.ContactMessage) (*models.ContactMessage, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.CreateMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	created, err := scanContactMessage(r.db.QueryRow(query,
+		message.Name,
+		message.Email,
+		message.Subject,
+		message.Message,
+		message.IPAddress,
+		message.UserAgent,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create contact message: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListMessages returns a page of messages; a nil handled filter returns every message
+func (r *ContactRepository) ListMessages(handled *bool, limit, offset int) ([]models.ContactMessage, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.ListMessages)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.CountMessages)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var total int
+	if err := r.db.QueryRow(countQuery, handled).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count contact messages: %w", err)
+	}
+
+	rows, err := r.db.Query(query, handled, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list contact messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []models.ContactMessage{}
+	for rows.Next() {
+		message, err := scanContactMessage(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan contact message: %w", err)
+		}
+		messages = append(messages, *message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate contact messages: %w", err)
+	}
+
+	return messages, total, nil
+}
+
+func (r *ContactRepository) GetMessageByID(id int) (*models.ContactMessage, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.GetMessageByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	message, err := scanContactMessage(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get contact message by ID: %w", err)
+	}
+
+	return message, nil
+}
+
+func (r *ContactRepository) MarkMessageHandled(id int) (*models.ContactMessage, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.MarkMessageHandled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	message, err := scanContactMessage(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to mark contact message as handled: %w", err)
+	}
+
+	return message, nil
+}
+
+func (r *ContactRepository) DeleteMessage(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Contact.DeleteMessage)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete contact message: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanContactMessage(row rowScanner) (*models.ContactMessage, error) {
+	message := &models.ContactMessage{}
+	err := row.Scan(
+		&message.ID,
+		&message.Name,
+		&message.Email,
+		&message.Subject,
+		&message.Message,
+		&message.IPAddress,
+		&message.UserAgent,
+		&message.Handled,
+		&message.HandledAt,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrDuplicateSlug       = errors.New("slug already exists")
+	ErrDuplicateMedia      = errors.New("media with the same content already exists")
+	ErrDuplicateUsername   = errors.New("username already exists")
+	ErrLastActiveOwner     = errors.New("cannot remove the last active owner")
+	ErrDuplicateCredential = errors.New("credential is already registered")
+)
+
+const uniqueViolationCode = "23505"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
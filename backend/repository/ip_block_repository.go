@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type IPBlockRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewIPBlockRepository(db *sql.DB) *IPBlockRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &IPBlockRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *IPBlockRepository) CreateIPBlock(block *models.IPBlock) (*models.IPBlock, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.IPBlock.CreateIPBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	created, err := scanIPBlock(r.db.QueryRow(query, block.Network, block.Scope, block.Reason, block.CreatedBy, block.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IP block: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListActiveIPBlocks returns the blocks that have not expired at now, newest first
+func (r *IPBlockRepository) ListActiveIPBlocks(now time.Time) ([]models.IPBlock, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.IPBlock.ListActiveIPBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []models.IPBlock{}
+	for rows.Next() {
+		block, err := scanIPBlock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan IP block: %w", err)
+		}
+		blocks = append(blocks, *block)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate IP blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+func (r *IPBlockRepository) DeleteIPBlock(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.IPBlock.DeleteIPBlock)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete IP block: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *IPBlockRepository) CleanupIPBlocks(expiredBefore time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.IPBlock.CleanupIPBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, expiredBefore)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup IP blocks: %w", err)
+	}
+
+	return nil
+}
+
+func scanIPBlock(row rowScanner) (*models.IPBlock, error) {
+	var block models.IPBlock
+	err := row.Scan(
+		&block.ID,
+		&block.Network,
+		&block.Scope,
+		&block.Reason,
+		&block.CreatedBy,
+		&block.ExpiresAt,
+		&block.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
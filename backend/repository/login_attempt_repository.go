@@ -26,13 +26,14 @@ func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
 	}
 }
 
-func (r *LoginAttemptRepository) CreateLoginAttempt(ipAddress, userAgent string, success bool, details *string) error {
+// CreateLoginAttempt records a login attempt. adminID is only known for successful logins.
+func (r *LoginAttemptRepository) CreateLoginAttempt(ipAddress, userAgent string, success bool, details *string, adminID *int) error {
 	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.CreateLoginAttempt)
 	if err != nil {
 		return fmt.Errorf("failed to get query: %w", err)
 	}
 
-	_, err = r.db.Exec(query, ipAddress, userAgent, success, details)
+	_, err = r.db.Exec(query, ipAddress, userAgent, success, details, adminID)
 	if err != nil {
 		return fmt.Errorf("failed to create login attempt: %w", err)
 	}
@@ -54,19 +55,11 @@ func (r *LoginAttemptRepository) GetRecentLoginAttempts(ipAddress string, since
 
 	var attempts []models.LoginAttempt
 	for rows.Next() {
-		var attempt models.LoginAttempt
-		err := rows.Scan(
-			&attempt.ID,
-			&attempt.IPAddress,
-			&attempt.UserAgent,
-			&attempt.Success,
-			&attempt.AttemptAt,
-			&attempt.Details,
-		)
+		attempt, err := scanLoginAttempt(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan login attempt: %w", err)
 		}
-		attempts = append(attempts, attempt)
+		attempts = append(attempts, *attempt)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -76,6 +69,125 @@ func (r *LoginAttemptRepository) GetRecentLoginAttempts(ipAddress string, since
 	return attempts, nil
 }
 
+// ListLoginAttempts returns a page of login attempts matching the filter, newest first, and the
+// total number that match
+func (r *LoginAttemptRepository) ListLoginAttempts(filter models.LoginAttemptFilter, limit, offset int) ([]models.LoginAttempt, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.ListLoginAttempts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.CountLoginAttempts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	args := []interface{}{filter.Success, filter.IPAddress, filter.From, filter.To}
+
+	var total int
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count login attempts: %w", err)
+	}
+
+	attempts, err := r.listLoginAttempts(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, total, nil
+}
+
+// ListSuccessfulLoginsByAdmin returns an admin's most recent successful logins
+func (r *LoginAttemptRepository) ListSuccessfulLoginsByAdmin(adminID, limit int) ([]models.LoginAttempt, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.ListSuccessfulLoginsByAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	return r.listLoginAttempts(query, adminID, limit)
+}
+
+// GetLoginAttemptStats aggregates the login attempts made from from until to. topN limits the
+// offending IPs and user agents returned.
+func (r *LoginAttemptRepository) GetLoginAttemptStats(from, to time.Time, topN int) (*models.LoginAttemptStats, error) {
+	totalsQuery, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.GetLoginAttemptTotals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	hourlyQuery, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.GetFailuresPerHour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	ipQuery, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.GetTopFailedIPs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	userAgentQuery, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.GetUserAgentCounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	stats := &models.LoginAttemptStats{
+		From:            from,
+		To:              to,
+		FailuresPerHour: []models.HourlyFailureCount{},
+		TopFailedIPs:    []models.IPFailureCount{},
+		UserAgents:      []models.UserAgentCount{},
+	}
+
+	err = r.db.QueryRow(totalsQuery, from, to).Scan(
+		&stats.TotalAttempts,
+		&stats.FailedAttempts,
+		&stats.DistinctIPs,
+		&stats.DistinctUserAgents,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count login attempts: %w", err)
+	}
+
+	err = r.queryStats(hourlyQuery, func(rows *sql.Rows) error {
+		var count models.HourlyFailureCount
+		if err := rows.Scan(&count.Hour, &count.Failures); err != nil {
+			return err
+		}
+		count.Hour = count.Hour.UTC()
+		stats.FailuresPerHour = append(stats.FailuresPerHour, count)
+		return nil
+	}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failures per hour: %w", err)
+	}
+
+	err = r.queryStats(ipQuery, func(rows *sql.Rows) error {
+		var count models.IPFailureCount
+		if err := rows.Scan(&count.IPAddress, &count.Failures, &count.LastAttemptAt); err != nil {
+			return err
+		}
+		stats.TopFailedIPs = append(stats.TopFailedIPs, count)
+		return nil
+	}, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top failed IPs: %w", err)
+	}
+
+	err = r.queryStats(userAgentQuery, func(rows *sql.Rows) error {
+		var count models.UserAgentCount
+		if err := rows.Scan(&count.UserAgent, &count.Attempts, &count.Failures); err != nil {
+			return err
+		}
+		stats.UserAgents = append(stats.UserAgents, count)
+		return nil
+	}, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user agent counts: %w", err)
+	}
+
+	return stats, nil
+}
+
 func (r *LoginAttemptRepository) GetFailedLoginAttempts(ipAddress string, since time.Time) (int, error) {
 	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.GetFailedLoginAttempts)
 	if err != nil {
@@ -91,6 +203,22 @@ func (r *LoginAttemptRepository) GetFailedLoginAttempts(ipAddress string, since
 	return count, nil
 }
 
+// CountSuccessfulLogins counts the admin's successful logins from ipAddress
+func (r *LoginAttemptRepository) CountSuccessfulLogins(ipAddress string, adminID int) (int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.CountSuccessfulLogins)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var count int
+	err = r.db.QueryRow(query, ipAddress, adminID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get successful login count: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *LoginAttemptRepository) CleanupOldLoginAttempts(olderThan time.Time) error {
 	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginAttempt.CleanupOldLoginAttempts)
 	if err != nil {
@@ -109,3 +237,60 @@ func (r *LoginAttemptRepository) CleanupOldLoginAttempts(olderThan time.Time) er
 
 	return nil
 }
+
+func (r *LoginAttemptRepository) listLoginAttempts(query string, args ...interface{}) ([]models.LoginAttempt, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []models.LoginAttempt{}
+	for rows.Next() {
+		attempt, err := scanLoginAttempt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login attempt: %w", err)
+		}
+		attempts = append(attempts, *attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate login attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepository) queryStats(query string, scan func(*sql.Rows) error, args ...interface{}) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func scanLoginAttempt(row rowScanner) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.IPAddress,
+		&attempt.UserAgent,
+		&attempt.Success,
+		&attempt.AttemptAt,
+		&attempt.Details,
+		&attempt.AdminID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempt, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type LoginLockoutRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewLoginLockoutRepository(db *sql.DB) *LoginLockoutRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &LoginLockoutRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+// GetLoginLockouts returns the records for a username and an IP, whichever exist
+func (r *LoginLockoutRepository) GetLoginLockouts(username, ipAddress string) ([]models.LoginLockout, error) {
+	return r.listLockouts(database.QueryKeys.LoginLockout.GetLoginLockouts, username, ipAddress)
+}
+
+// ListActiveLoginLockouts returns every record still holding back logins at the given time
+func (r *LoginLockoutRepository) ListActiveLoginLockouts(now time.Time) ([]models.LoginLockout, error) {
+	return r.listLockouts(database.QueryKeys.LoginLockout.ListActiveLoginLockouts, now)
+}
+
+// ClaimLoginAttempt takes the next attempt for a username or IP and holds off others until
+// holdUntil. It returns nil when an attempt may not be made yet.
+func (r *LoginLockoutRepository) ClaimLoginAttempt(scope, subject string, now, holdUntil time.Time) (*models.LoginLockout, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.ClaimLoginAttempt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	lockout, err := scanLoginLockout(r.db.QueryRow(query, scope, subject, now, holdUntil))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim login attempt: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// ReleaseLoginAttempt lifts the hold set by ClaimLoginAttempt, unless it has since been replaced
+func (r *LoginLockoutRepository) ReleaseLoginAttempt(scope, subject string, holdUntil time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.ReleaseLoginAttempt)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, scope, subject, holdUntil)
+	if err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+
+	return nil
+}
+
+// RecordLoginFailure counts a failure, starting again from one when the previous failure
+// was before resetBefore
+func (r *LoginLockoutRepository) RecordLoginFailure(scope, subject string, at, resetBefore time.Time) (*models.LoginLockout, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.RecordLoginFailure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	lockout, err := scanLoginLockout(r.db.QueryRow(query, scope, subject, at, resetBefore))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return lockout, nil
+}
+
+func (r *LoginLockoutRepository) SetLoginLockoutTimes(scope, subject string, nextAttemptAt time.Time, lockedUntil *time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.SetLoginLockoutTimes)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, scope, subject, nextAttemptAt, lockedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to set login lockout: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLoginLockout forgets the failures of a username or IP, reporting whether any were recorded
+func (r *LoginLockoutRepository) DeleteLoginLockout(scope, subject string) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.DeleteLoginLockout)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, scope, subject)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete login lockout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete login lockout: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CleanupLoginLockouts deletes records with no failure since failedBefore and no lock in force at now
+func (r *LoginLockoutRepository) CleanupLoginLockouts(failedBefore, now time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.LoginLockout.CleanupLoginLockouts)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, failedBefore, now)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup login lockouts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoginLockoutRepository) listLockouts(key string, args ...interface{}) ([]models.LoginLockout, error) {
+	query, err := r.queryLoader.GetQuery(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login lockouts: %w", err)
+	}
+	defer rows.Close()
+
+	lockouts := []models.LoginLockout{}
+	for rows.Next() {
+		lockout, err := scanLoginLockout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login lockout: %w", err)
+		}
+		lockouts = append(lockouts, *lockout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate login lockouts: %w", err)
+	}
+
+	return lockouts, nil
+}
+
+func scanLoginLockout(row rowScanner) (*models.LoginLockout, error) {
+	lockout := &models.LoginLockout{}
+	err := row.Scan(
+		&lockout.Scope,
+		&lockout.Subject,
+		&lockout.FailedAttempts,
+		&lockout.LastFailedAt,
+		&lockout.NextAttemptAt,
+		&lockout.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockout, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type MediaRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewMediaRepository(db *sql.DB) *MediaRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &MediaRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *MediaRepository) CreateMedia(media *models.Media) (*models.Media, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Media.CreateMedia)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	created, err := scanMedia(r.db.QueryRow(query,
+		media.ObjectKey,
+		media.ContentHash,
+		media.Filename,
+		media.MimeType,
+		media.SizeBytes,
+		media.Width,
+		media.Height,
+		media.AltText,
+		media.UploadedBy,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateMedia
+		}
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListMedia returns a page of media, optionally filtered by a filename/alt text search and MIME type
+func (r *MediaRepository) ListMedia(search, mimeType string, limit, offset int) ([]models.Media, int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Media.ListMedia)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	countQuery, err := r.queryLoader.GetQuery(database.QueryKeys.Media.CountMedia)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var total int
+	if err := r.db.QueryRow(countQuery, search, mimeType).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	rows, err := r.db.Query(query, search, mimeType, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list media: %w", err)
+	}
+	defer rows.Close()
+
+	media := []models.Media{}
+	for rows.Next() {
+		item, err := scanMedia(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan media: %w", err)
+		}
+		media = append(media, *item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate media: %w", err)
+	}
+
+	return media, total, nil
+}
+
+func (r *MediaRepository) GetMediaByID(id int) (*models.Media, error) {
+	return r.getMedia(database.QueryKeys.Media.GetMediaByID, id)
+}
+
+func (r *MediaRepository) GetMediaByKey(objectKey string) (*models.Media, error) {
+	return r.getMedia(database.QueryKeys.Media.GetMediaByKey, objectKey)
+}
+
+func (r *MediaRepository) GetMediaByHash(contentHash string) (*models.Media, error) {
+	return r.getMedia(database.QueryKeys.Media.GetMediaByHash, contentHash)
+}
+
+func (r *MediaRepository) DeleteMedia(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Media.DeleteMedia)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *MediaRepository) getMedia(queryKey string, arg interface{}) (*models.Media, error) {
+	query, err := r.queryLoader.GetQuery(queryKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	media, err := scanMedia(r.db.QueryRow(query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return media, nil
+}
+
+func scanMedia(row rowScanner) (*models.Media, error) {
+	var media models.Media
+	err := row.Scan(
+		&media.ID,
+		&media.ObjectKey,
+		&media.ContentHash,
+		&media.Filename,
+		&media.MimeType,
+		&media.SizeBytes,
+		&media.Width,
+		&media.Height,
+		&media.AltText,
+		&media.UploadedBy,
+		&media.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type PasswordResetRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewPasswordResetRepository(db *sql.DB) *PasswordResetRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &PasswordResetRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *PasswordResetRepository) CreateResetToken(adminID int, tokenHash string, createdBy *int, expiresAt time.Time) (*models.PasswordResetToken, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.PasswordReset.CreateResetToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	token, err := scanPasswordResetToken(r.db.QueryRow(query, adminID, tokenHash, createdBy, expiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ConsumeResetToken marks an unused, unexpired token as used and returns it, or nil if no such token exists
+func (r *PasswordResetRepository) ConsumeResetToken(tokenHash string) (*models.PasswordResetToken, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.PasswordReset.ConsumeResetToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	token, err := scanPasswordResetToken(r.db.QueryRow(query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	return token, nil
+}
+
+// InvalidateResetTokens marks every outstanding token for an admin as used
+func (r *PasswordResetRepository) InvalidateResetTokens(adminID int) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.PasswordReset.InvalidateResetTokens)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, adminID)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+// CleanupResetTokens deletes tokens that expired or were used before the cutoff
+func (r *PasswordResetRepository) CleanupResetTokens(cutoff time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.PasswordReset.CleanupResetTokens)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup password reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+func scanPasswordResetToken(row rowScanner) (*models.PasswordResetToken, error) {
+	token := &models.PasswordResetToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.AdminID,
+		&token.CreatedBy,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ProjectRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+	typeMap     *pgtype.Map
+}
+
+func NewProjectRepository(db *sql.DB) *ProjectRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &ProjectRepository{
+		db:          db,
+		queryLoader: queryLoader,
+		typeMap:     pgtype.NewMap(),
+	}
+}
+
+func (r *ProjectRepository) ListProjects(featuredOnly bool) ([]models.Project, error) {
+	key := database.QueryKeys.Project.ListProjects
+	if featuredOnly {
+		key = database.QueryKeys.Project.ListFeaturedProjects
+	}
+
+	query, err := r.queryLoader.GetQuery(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		project, err := r.scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, *project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (r *ProjectRepository) GetProjectBySlug(slug string) (*models.Project, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Project.GetProjectBySlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	project, err := r.scanProject(r.db.QueryRow(query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get project by slug: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) GetProjectByID(id int) (*models.Project, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Project.GetProjectByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	project, err := r.scanProject(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get project by ID: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) CreateProject(project *models.Project) (*models.Project, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Project.CreateProject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	created, err := r.scanProject(r.db.QueryRow(query,
+		project.Slug,
+		project.Title,
+		project.Summary,
+		project.Description,
+		project.ImageURL,
+		project.ImageAlt,
+		project.TechStack,
+		project.RepositoryURL,
+		project.LiveURL,
+		project.Featured,
+		project.SortOrder,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *ProjectRepository) UpdateProject(id int, project *models.Project) (*models.Project, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Project.UpdateProject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	updated, err := r.scanProject(r.db.QueryRow(query,
+		project.Slug,
+		project.Title,
+		project.Summary,
+		project.Description,
+		project.ImageURL,
+		project.ImageAlt,
+		project.TechStack,
+		project.RepositoryURL,
+		project.LiveURL,
+		project.Featured,
+		project.SortOrder,
+		id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *ProjectRepository) DeleteProject(id int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Project.DeleteProject)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *ProjectRepository) scanProject(row rowScanner) (*models.Project, error) {
+	project := &models.Project{}
+	err := row.Scan(
+		&project.ID,
+		&project.Slug,
+		&project.Title,
+		&project.Summary,
+		&project.Description,
+		&project.ImageURL,
+		&project.ImageAlt,
+		r.typeMap.SQLScanner(&project.TechStack),
+		&project.RepositoryURL,
+		&project.LiveURL,
+		&project.Featured,
+		&project.SortOrder,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.TechStack == nil {
+		project.TechStack = []string{}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+)
+
+type RecoveryCodeRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewRecoveryCodeRepository(db *sql.DB) *RecoveryCodeRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &RecoveryCodeRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+// ReplaceRecoveryCodes discards an admin's existing recovery codes and stores the given hashes
+func (r *RecoveryCodeRepository) ReplaceRecoveryCodes(adminID int, codeHashes []string) error {
+	deleteQuery, err := r.queryLoader.GetQuery(database.QueryKeys.RecoveryCode.DeleteRecoveryCodes)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	createQuery, err := r.queryLoader.GetQuery(database.QueryKeys.RecoveryCode.CreateRecoveryCode)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(deleteQuery, adminID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.Exec(createQuery, adminID, codeHash); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code as used, reporting whether one matched
+func (r *RecoveryCodeRepository) ConsumeRecoveryCode(adminID int, codeHash string) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RecoveryCode.ConsumeRecoveryCode)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, adminID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *RecoveryCodeRepository) DeleteRecoveryCodes(adminID int) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RecoveryCode.DeleteRecoveryCodes)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, adminID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes an admin has left
+func (r *RecoveryCodeRepository) CountRecoveryCodes(adminID int) (int, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RecoveryCode.CountRecoveryCodes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	var count int
+	err = r.db.QueryRow(query, adminID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+// RevokedTokenRepository persists the access token revocation list so it survives restarts
+type RevokedTokenRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewRevokedTokenRepository(db *sql.DB) *RevokedTokenRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &RevokedTokenRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+func (r *RevokedTokenRepository) SaveRevokedToken(token models.RevokedToken) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RevokedToken.CreateRevokedToken)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, token.TokenID, token.AdminID, token.Reason, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save revoked token: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevokedTokens returns the revoked tokens that have not expired by now
+func (r *RevokedTokenRepository) ListRevokedTokens(now time.Time) ([]models.RevokedToken, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RevokedToken.ListRevokedTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []models.RevokedToken{}
+	for rows.Next() {
+		var token models.RevokedToken
+		if err := rows.Scan(&token.TokenID, &token.AdminID, &token.Reason, &token.ExpiresAt, &token.RevokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate revoked tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// CleanupRevokedTokens deletes entries for tokens that have expired, since they are rejected anyway
+func (r *RevokedTokenRepository) CleanupRevokedTokens(now time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.RevokedToken.CleanupRevokedTokens)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, now)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup revoked tokens: %w", err)
+	}
+
+	return nil
+}
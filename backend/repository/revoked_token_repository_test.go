@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+func TestRevokedTokensCleanupKeepsUnexpired(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := NewRevokedTokenRepository(db)
+	now := time.Now()
+
+	active := models.RevokedToken{TokenID: dbtest.UniqueName("active-"), Reason: "test", ExpiresAt: now.Add(time.Hour)}
+	expired := models.RevokedToken{TokenID: dbtest.UniqueName("expired-"), Reason: "test", ExpiresAt: now.Add(-time.Minute)}
+	for _, token := range []models.RevokedToken{active, expired} {
+		if err := repo.SaveRevokedToken(token); err != nil {
+			t.Fatalf("failed to save revoked token: %v", err)
+		}
+	}
+
+	listed := func() map[string]bool {
+		tokens, err := repo.ListRevokedTokens(now)
+		if err != nil {
+			t.Fatalf("failed to list revoked tokens: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, token := range tokens {
+			ids[token.TokenID] = true
+		}
+		return ids
+	}
+
+	if ids := listed(); !ids[active.TokenID] || ids[expired.TokenID] {
+		t.Errorf("listing should return only the unexpired token, got active=%v expired=%v", ids[active.TokenID], ids[expired.TokenID])
+	}
+
+	if err := repo.CleanupRevokedTokens(now); err != nil {
+		t.Fatalf("failed to clean up revoked tokens: %v", err)
+	}
+
+	var remaining int
+	if err := db.QueryRow("SELECT COUNT(*) FROM revoked_tokens WHERE jti = ANY($1)", []string{active.TokenID, expired.TokenID}).Scan(&remaining); err != nil {
+		t.Fatalf("failed to count revoked tokens: %v", err)
+	}
+	if remaining != 1 || !listed()[active.TokenID] {
+		t.Errorf("cleanup should keep only the unexpired token, %d remain", remaining)
+	}
+}
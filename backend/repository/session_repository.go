@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+)
+
+type SessionRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &SessionRepository{
+		db:          db,
+		queryLoader: queryLoader,
+	}
+}
+
+// CreateSession stores a new session along with the ID and expiry of its first access token
+func (r *SessionRepository) CreateSession(id string, adminID int, refreshTokenHash, userAgent, ipAddress string, expiresAt time.Time, accessTokenID string, accessExpiresAt time.Time) (*models.Session, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Session.CreateSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	session, err := scanSession(r.db.QueryRow(query, id, adminID, refreshTokenHash, nullableString(userAgent), nullableString(ipAddress), expiresAt, accessTokenID, accessExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetActiveSession returns a session that is neither revoked nor expired, or nil
+func (r *SessionRepository) GetActiveSession(id string) (*models.Session, error) {
+	return r.getSession(database.QueryKeys.Session.GetActiveSession, id)
+}
+
+// RotateSession swaps the session's refresh token hash, but only if oldHash is still current.
+// It returns nil when the session was revoked, expired or already rotated.
+func (r *SessionRepository) RotateSession(id, oldHash, newHash string, expiresAt time.Time, userAgent, ipAddress, accessTokenID string, accessExpiresAt time.Time) (*models.Session, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Session.RotateSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	session, err := scanSession(r.db.QueryRow(query, id, oldHash, newHash, expiresAt, nullableString(userAgent), nullableString(ipAddress), accessTokenID, accessExpiresAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) TouchSession(id, ipAddress string) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Session.TouchSession)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, id, nullableString(ipAddress))
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveSessions returns an admin's live sessions, most recently used first
+func (r *SessionRepository) ListActiveSessions(adminID int) ([]models.Session, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Session.ListActiveSessions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one of an admin's sessions. It returns the revoked session, or nil
+// when no live session matched.
+func (r *SessionRepository) RevokeSession(id string, adminID int) (*models.Session, error) {
+	sessions, err := r.revokeSessions(database.QueryKeys.Session.RevokeSession, id, adminID)
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+
+	return &sessions[0], nil
+}
+
+// RevokeOtherSessions revokes every session of an admin except keepID, returning the sessions revoked
+func (r *SessionRepository) RevokeOtherSessions(adminID int, keepID string) ([]models.Session, error) {
+	return r.revokeSessions(database.QueryKeys.Session.RevokeOtherSessions, adminID, keepID)
+}
+
+func (r *SessionRepository) RevokeAllSessions(adminID int) ([]models.Session, error) {
+	return r.revokeSessions(database.QueryKeys.Session.RevokeAllSessions, adminID)
+}
+
+// CleanupExpiredSessions deletes sessions that expired or were revoked before the cutoff
+func (r *SessionRepository) CleanupExpiredSessions(cutoff time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.Session.CleanupExpiredSessions)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup expired sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) getSession(queryKey string, arg interface{}) (*models.Session, error) {
+	query, err := r.queryLoader.GetQuery(queryKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	session, err := scanSession(r.db.QueryRow(query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) revokeSessions(queryKey string, args ...interface{}) ([]models.Session, error) {
+	query, err := r.queryLoader.GetQuery(queryKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func scanSession(row rowScanner) (*models.Session, error) {
+	session := &models.Session{}
+	err := row.Scan(
+		&session.ID,
+		&session.AdminID,
+		&session.RefreshTokenHash,
+		&session.PreviousTokenHash,
+		&session.RotatedAt,
+		&session.AccessTokenID,
+		&session.AccessExpiresAt,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
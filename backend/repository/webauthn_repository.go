@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WebAuthnRepository struct {
+	db          *sql.DB
+	queryLoader *database.QueryLoader
+	typeMap     *pgtype.Map
+}
+
+func NewWebAuthnRepository(db *sql.DB) *WebAuthnRepository {
+	queryLoader, err := database.NewQueryLoader()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load queries: %v\n", err)
+	}
+
+	return &WebAuthnRepository{
+		db:          db,
+		queryLoader: queryLoader,
+		typeMap:     pgtype.NewMap(),
+	}
+}
+
+func (r *WebAuthnRepository) CreateCredential(credential *models.WebAuthnCredential) (*models.WebAuthnCredential, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.CreateCredential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	if credential.Transports == nil {
+		credential.Transports = []string{}
+	}
+
+	created, err := r.scanCredential(r.db.QueryRow(query,
+		credential.AdminID,
+		credential.CredentialID,
+		credential.UserHandle,
+		credential.PublicKey,
+		credential.AttestationType,
+		credential.AAGUID,
+		credential.SignCount,
+		credential.Transports,
+		credential.BackupEligible,
+		credential.BackupState,
+		credential.Name,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateCredential
+		}
+		return nil, fmt.Errorf("failed to create WebAuthn credential: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListCredentials returns an admin's registered credentials, oldest first
+func (r *WebAuthnRepository) ListCredentials(adminID int) ([]models.WebAuthnCredential, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.ListCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	rows, err := r.db.Query(query, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+	defer rows.Close()
+
+	credentials := []models.WebAuthnCredential{}
+	for rows.Next() {
+		credential, err := r.scanCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan WebAuthn credential: %w", err)
+		}
+		credentials = append(credentials, *credential)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate WebAuthn credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (r *WebAuthnRepository) GetCredentialByCredentialID(credentialID []byte) (*models.WebAuthnCredential, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.GetCredentialByCredentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	credential, err := r.scanCredential(r.db.QueryRow(query, credentialID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get WebAuthn credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+// RecordCredentialUse stores the sign count and backup state reported by the latest assertion
+func (r *WebAuthnRepository) RecordCredentialUse(id int, signCount int64, backupState bool) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.RecordCredentialUse)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, id, signCount, backupState)
+	if err != nil {
+		return fmt.Errorf("failed to record WebAuthn credential use: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCredential removes one of an admin's credentials, reporting whether it existed
+func (r *WebAuthnRepository) DeleteCredential(id, adminID int) (bool, error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.DeleteCredential)
+	if err != nil {
+		return false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id, adminID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete WebAuthn credential: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *WebAuthnRepository) CreateCeremony(id string, adminID *int, ceremonyType string, sessionData []byte, expiresAt time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.CreateCeremony)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, id, adminID, ceremonyType, string(sessionData), expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create WebAuthn ceremony: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeCeremony deletes an unexpired ceremony and returns its admin and session data.
+// found is false when no such ceremony exists.
+func (r *WebAuthnRepository) ConsumeCeremony(id, ceremonyType string) (adminID *int, sessionData []byte, found bool, err error) {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.ConsumeCeremony)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to get query: %w", err)
+	}
+
+	err = r.db.QueryRow(query, id, ceremonyType).Scan(&adminID, &sessionData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, false, nil
+		}
+		return nil, nil, false, fmt.Errorf("failed to consume WebAuthn ceremony: %w", err)
+	}
+
+	return adminID, sessionData, true, nil
+}
+
+// CleanupCeremonies deletes ceremonies that expired before the cutoff
+func (r *WebAuthnRepository) CleanupCeremonies(cutoff time.Time) error {
+	query, err := r.queryLoader.GetQuery(database.QueryKeys.WebAuthn.CleanupCeremonies)
+	if err != nil {
+		return fmt.Errorf("failed to get query: %w", err)
+	}
+
+	_, err = r.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup WebAuthn ceremonies: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebAuthnRepository) scanCredential(row rowScanner) (*models.WebAuthnCredential, error) {
+	credential := &models.WebAuthnCredential{}
+	err := row.Scan(
+		&credential.ID,
+		&credential.AdminID,
+		&credential.CredentialID,
+		&credential.UserHandle,
+		&credential.PublicKey,
+		&credential.AttestationType,
+		&credential.AAGUID,
+		&credential.SignCount,
+		r.typeMap.SQLScanner(&credential.Transports),
+		&credential.BackupEligible,
+		&credential.BackupState,
+		&credential.Name,
+		&credential.LastUsedAt,
+		&credential.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.Transports == nil {
+		credential.Transports = []string{}
+	}
+
+	return credential, nil
+}
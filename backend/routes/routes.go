@@ -1,21 +1,26 @@
 package routes
 
 import (
-	"os"
-	"time"
+	"fmt"
 
 	"github.com/Wildcard209/portfolio-webapplication/auth"
 	"github.com/Wildcard209/portfolio-webapplication/config"
 	"github.com/Wildcard209/portfolio-webapplication/handlers"
 	"github.com/Wildcard209/portfolio-webapplication/middleware"
+	"github.com/Wildcard209/portfolio-webapplication/notify"
 	"github.com/Wildcard209/portfolio-webapplication/repository"
 	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/Wildcard209/portfolio-webapplication/storage"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func SetupRoutes(r *gin.Engine, cfg *config.Config, authService *auth.AuthService) {
+	ipAccess := setupIPAccessService(cfg)
+	r.Use(middleware.IPAccessMiddleware(ipAccess))
+
 	r.Use(middleware.HeaderSanitizationMiddleware(middleware.NewHeaderSanitizationConfig()))
 
 	r.Use(middleware.CORSMiddleware())
@@ -24,10 +29,17 @@ func SetupRoutes(r *gin.Engine, cfg *config.Config, authService *auth.AuthServic
 
 	r.Use(middleware.RequestBodySizeLimitMiddleware(middleware.GetRequestBodySizeLimit()))
 
+	r.Use(middleware.RequestIDMiddleware())
+
 	r.Use(middleware.LoggingMiddleware())
 
 	r.Use(middleware.RateLimitViolationMiddleware())
 
+	r.GET("/.well-known/jwks.json",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+		handlers.NewJWKSHandler(authService).GetJWKS,
+	)
+
 	api := r.Group("/api")
 	{
 		api.GET("/test",
@@ -41,21 +53,102 @@ func SetupRoutes(r *gin.Engine, cfg *config.Config, authService *auth.AuthServic
 
 		api.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-		if cfg.MinioClient != nil {
-			setupAssetRoutes(api, cfg)
+		var sessionService *services.SessionService
+		var apiKeyService *services.APIKeyService
+		var auditService *services.AuditService
+		if cfg.DB != nil {
+			sessionService = services.NewSessionService(authService, repository.NewAdminRepository(cfg.DB), repository.NewSessionRepository(cfg.DB))
+			apiKeyService = services.NewAPIKeyService(repository.NewAPIKeyRepository(cfg.DB), repository.NewAdminRepository(cfg.DB))
+			auditService = services.NewAuditService(repository.NewAuditLogRepository(cfg.DB))
+
+			// Only affects routes registered from here on; it skips everything outside /api/admin
+			api.Use(middleware.AuditMiddleware(auditService))
+		}
+
+		assetService := setupAssetService(cfg)
+		if assetService != nil {
+			setupAssetRoutes(api, cfg, authService, assetService, sessionService, apiKeyService)
 		}
 
 		if cfg.DB != nil {
-			setupAdminRoutes(api, cfg, authService)
+			notifier := setupNotifier(cfg)
+			mfaService := services.NewMFAService(repository.NewAdminRepository(cfg.DB), repository.NewRecoveryCodeRepository(cfg.DB), cfg.MFA.TOTPIssuer)
+			webauthnService := setupWebAuthnService(cfg)
+			loginProtection := services.NewLoginProtectionService(repository.NewLoginLockoutRepository(cfg.DB), cfg.LoginProtection)
+			adminProtected, adminAccount := setupAdminRoutes(api, cfg, authService, sessionService, apiKeyService, mfaService, webauthnService, loginProtection, notifier)
+			setupAdminUserRoutes(api, adminAccount, cfg, authService, sessionService, apiKeyService)
+			setupSessionRoutes(adminAccount, cfg, sessionService)
+			setupMFARoutes(adminAccount, cfg, mfaService)
+			setupAPIKeyRoutes(adminAccount, cfg, apiKeyService)
+			setupLoginLockoutRoutes(adminAccount, cfg, loginProtection)
+			setupLoginHistoryRoutes(adminAccount, cfg)
+			setupAuditLogRoutes(adminAccount, cfg, auditService)
+			setupIPBlockRoutes(adminAccount, cfg, ipAccess)
+			setupProjectRoutes(api, adminProtected, cfg)
+			setupBlogRoutes(api, adminProtected, cfg)
+			setupContactRoutes(api, adminProtected, cfg, notifier)
+			if assetService != nil {
+				setupMediaRoutes(api, adminProtected, cfg, assetService)
+			}
 		}
 	}
 }
 
-func setupAdminRoutes(api *gin.RouterGroup, cfg *config.Config, authService *auth.AuthService) {
+func setupAssetService(cfg *config.Config) *services.AssetService {
+	store, err := storage.NewObjectStore(cfg.Storage, cfg.MinioClient)
+	if err != nil {
+		fmt.Printf("Warning: asset storage unavailable, asset routes disabled: %v\n", err)
+		return nil
+	}
+
+	imageProcessor := utils.NewImageProcessor(cfg.Images.VariantWidths, cfg.Images.WebPVariants, cfg.Images.JPEGQuality, cfg.Images.MaxPixels)
+	return services.NewAssetService(store, imageProcessor)
+}
+
+func setupIPAccessService(cfg *config.Config) *services.IPAccessService {
+	var blockRepo *repository.IPBlockRepository
+	if cfg.DB != nil {
+		blockRepo = repository.NewIPBlockRepository(cfg.DB)
+	}
+
+	return services.NewIPAccessService(cfg.IPAccess, blockRepo)
+}
+
+func setupNotifier(cfg *config.Config) *notify.Notifier {
+	notifier, err := notify.NewNotifierFromConfig(cfg.Notifications)
+	if err != nil {
+		fmt.Printf("Warning: email notifications disabled: %v\n", err)
+		return nil
+	}
+
+	return notifier
+}
+
+func setupWebAuthnService(cfg *config.Config) *services.WebAuthnService {
+	verifier, err := auth.NewWebAuthnVerifier(cfg.MFA.WebAuthnRPID, cfg.MFA.WebAuthnRPName, cfg.MFA.WebAuthnOrigins)
+	if err != nil {
+		fmt.Printf("Warning: passkey login disabled: %v\n", err)
+		return nil
+	}
+
+	return services.NewWebAuthnService(verifier, repository.NewWebAuthnRepository(cfg.DB), repository.NewAdminRepository(cfg.DB))
+}
+
+func setupAdminRoutes(
+	api *gin.RouterGroup,
+	cfg *config.Config,
+	authService *auth.AuthService,
+	sessionService *services.SessionService,
+	apiKeyService *services.APIKeyService,
+	mfaService *services.MFAService,
+	webauthnService *services.WebAuthnService,
+	loginProtection *services.LoginProtectionService,
+	notifier *notify.Notifier,
+) (*gin.RouterGroup, *gin.RouterGroup) {
 	adminRepo := repository.NewAdminRepository(cfg.DB)
 	loginAttemptRepo := repository.NewLoginAttemptRepository(cfg.DB)
 
-	adminHandler := handlers.NewAdminHandler(authService, adminRepo, loginAttemptRepo)
+	adminHandler := handlers.NewAdminHandler(authService, adminRepo, sessionService, mfaService, loginAttemptRepo, loginProtection, notifier)
 
 	adminGroup := api.Group("/admin")
 	{
@@ -65,51 +158,418 @@ func setupAdminRoutes(api *gin.RouterGroup, cfg *config.Config, authService *aut
 			adminHandler.Login,
 		)
 
+		adminGroup.POST("/login/mfa",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+			middleware.ValidateContentTypeMiddleware(),
+			adminHandler.VerifyMFA,
+		)
+
 		adminGroup.POST("/refresh",
 			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitRefresh, cfg.RateLimit),
 			adminHandler.RefreshToken,
 		)
 
 		protected := adminGroup.Group("")
-		protected.Use(middleware.AuthMiddleware(authService, adminRepo))
+		protected.Use(middleware.AuthMiddleware(authService, sessionService, apiKeyService))
+		protected.Use(middleware.APIKeyRateLimitMiddleware(cfg.RateLimit))
+
+		// Routes that manage the account itself are off limits to API keys
+		account := protected.Group("")
+		account.Use(middleware.RequireSession())
 		{
-			protected.POST("/logout",
+			account.POST("/logout",
 				middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
 				adminHandler.Logout,
 			)
 		}
+
+		if webauthnService != nil {
+			setupWebAuthnRoutes(adminGroup, account, cfg, webauthnService, adminHandler)
+		}
+
+		return protected, account
 	}
 }
 
-func setupAssetRoutes(api *gin.RouterGroup, cfg *config.Config) {
-	assetService := services.NewAssetService(cfg.MinioClient)
-	assetHandler := handlers.NewAssetHandler(assetService)
+func setupAdminUserRoutes(api *gin.RouterGroup, adminAccount *gin.RouterGroup, cfg *config.Config, authService *auth.AuthService, sessionService *services.SessionService, apiKeyService *services.APIKeyService) {
+	adminRepo := repository.NewAdminRepository(cfg.DB)
+	passwordService := services.NewPasswordService(
+		authService,
+		adminRepo,
+		repository.NewPasswordResetRepository(cfg.DB),
+		sessionService,
+	)
 
-	assetsGroup := api.Group("/assets")
+	adminUserHandler := handlers.NewAdminUserHandler(authService, adminRepo, sessionService, apiKeyService)
+	passwordHandler := handlers.NewPasswordHandler(passwordService, adminRepo)
+
+	// Reset tokens are redeemed without a session, so this is rate limited like login
+	api.POST("/admin/password/reset",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+		middleware.ValidateContentTypeMiddleware(),
+		passwordHandler.ResetPassword,
+	)
+
+	adminAccount.POST("/password",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+		middleware.ValidateContentTypeMiddleware(),
+		passwordHandler.ChangePassword,
+	)
+
+	adminAccount.PUT("/username",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+		middleware.ValidateContentTypeMiddleware(),
+		adminUserHandler.UpdateUsername,
+	)
+
+	adminUsers := adminAccount.Group("/users")
+	adminUsers.Use(middleware.RequirePermission(auth.PermissionUsersManage))
+	adminUsers.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminUsers.GET("", adminUserHandler.ListAdmins)
+		adminUsers.POST("",
+			middleware.ValidateContentTypeMiddleware(),
+			adminUserHandler.CreateAdmin,
+		)
+		adminUsers.POST("/:id/disable", adminUserHandler.DisableAdmin)
+		adminUsers.POST("/:id/enable", adminUserHandler.EnableAdmin)
+		adminUsers.DELETE("/:id", adminUserHandler.DeleteAdmin)
+		adminUsers.PUT("/:id/role",
+			middleware.ValidateContentTypeMiddleware(),
+			adminUserHandler.UpdateRole,
+		)
+		adminUsers.POST("/:id/password-reset", passwordHandler.IssuePasswordReset)
+	}
+}
+
+func setupSessionRoutes(adminAccount *gin.RouterGroup, cfg *config.Config, sessionService *services.SessionService) {
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+
+	adminSessions := adminAccount.Group("/sessions")
+	adminSessions.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminSessions.GET("", sessionHandler.ListSessions)
+		adminSessions.DELETE("", sessionHandler.RevokeOtherSessions)
+		adminSessions.DELETE("/:id", sessionHandler.RevokeSession)
+	}
+}
+
+func setupMFARoutes(adminAccount *gin.RouterGroup, cfg *config.Config, mfaService *services.MFAService) {
+	mfaHandler := handlers.NewMFAHandler(mfaService)
+
+	adminMFA := adminAccount.Group("/mfa")
+	{
+		adminMFA.GET("",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+			mfaHandler.GetStatus,
+		)
+		adminMFA.POST("/totp/setup",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+			mfaHandler.SetupTOTP,
+		)
+		// Code checks are rate limited like login to slow down guessing
+		adminMFA.POST("/totp/confirm",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+			middleware.ValidateContentTypeMiddleware(),
+			mfaHandler.ConfirmTOTP,
+		)
+		adminMFA.POST("/totp/disable",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+			middleware.ValidateContentTypeMiddleware(),
+			mfaHandler.DisableTOTP,
+		)
+	}
+}
+
+func setupWebAuthnRoutes(
+	adminGroup *gin.RouterGroup,
+	adminAccount *gin.RouterGroup,
+	cfg *config.Config,
+	webauthnService *services.WebAuthnService,
+	adminHandler *handlers.AdminHandler,
+) {
+	webauthnHandler := handlers.NewWebAuthnHandler(webauthnService, adminHandler)
+
+	adminGroup.POST("/webauthn/login/begin",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+		webauthnHandler.BeginLogin,
+	)
+	adminGroup.POST("/webauthn/login/finish",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitLogin, cfg.RateLimit),
+		middleware.ValidateContentTypeMiddleware(),
+		webauthnHandler.FinishLogin,
+	)
+
+	adminWebAuthn := adminAccount.Group("/webauthn")
+	adminWebAuthn.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminWebAuthn.POST("/register/begin", webauthnHandler.BeginRegistration)
+		adminWebAuthn.POST("/register/finish",
+			middleware.ValidateContentTypeMiddleware(),
+			webauthnHandler.FinishRegistration,
+		)
+		adminWebAuthn.GET("/credentials", webauthnHandler.ListCredentials)
+		adminWebAuthn.DELETE("/credentials/:id", webauthnHandler.DeleteCredential)
+	}
+}
+
+func setupAPIKeyRoutes(adminAccount *gin.RouterGroup, cfg *config.Config, apiKeyService *services.APIKeyService) {
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+
+	adminAPIKeys := adminAccount.Group("/api-keys")
+	adminAPIKeys.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
 	{
-		assetsGroup.GET("/hero-banner",
+		adminAPIKeys.GET("", apiKeyHandler.ListAPIKeys)
+		adminAPIKeys.POST("",
+			middleware.ValidateContentTypeMiddleware(),
+			apiKeyHandler.CreateAPIKey,
+		)
+		adminAPIKeys.DELETE("", apiKeyHandler.RevokeAllAPIKeys)
+		adminAPIKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+	}
+}
+
+func setupLoginLockoutRoutes(adminAccount *gin.RouterGroup, cfg *config.Config, loginProtection *services.LoginProtectionService) {
+	lockoutHandler := handlers.NewLoginLockoutHandler(loginProtection)
+
+	adminLockouts := adminAccount.Group("/lockouts")
+	adminLockouts.Use(middleware.RequirePermission(auth.PermissionSecurityManage))
+	adminLockouts.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminLockouts.GET("", lockoutHandler.ListLockouts)
+		adminLockouts.POST("/unlock",
+			middleware.ValidateContentTypeMiddleware(),
+			lockoutHandler.Unlock,
+		)
+	}
+}
+
+func setupLoginHistoryRoutes(adminAccount *gin.RouterGroup, cfg *config.Config) {
+	loginHistoryHandler := handlers.NewLoginHistoryHandler(repository.NewLoginAttemptRepository(cfg.DB))
+
+	adminAccount.GET("/logins",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+		loginHistoryHandler.GetRecentLogins,
+	)
+
+	adminLoginAttempts := adminAccount.Group("/login-attempts")
+	adminLoginAttempts.Use(middleware.RequirePermission(auth.PermissionSecurityManage))
+	adminLoginAttempts.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminLoginAttempts.GET("", loginHistoryHandler.ListLoginAttempts)
+		adminLoginAttempts.GET("/stats", loginHistoryHandler.GetLoginStats)
+	}
+}
+
+func setupAuditLogRoutes(adminAccount *gin.RouterGroup, cfg *config.Config, auditService *services.AuditService) {
+	auditLogHandler := handlers.NewAuditLogHandler(auditService)
+
+	adminAccount.GET("/audit-log",
+		middleware.RequirePermission(auth.PermissionSecurityManage),
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+		auditLogHandler.ListAuditLog,
+	)
+}
+
+func setupIPBlockRoutes(adminAccount *gin.RouterGroup, cfg *config.Config, ipAccess *services.IPAccessService) {
+	ipBlockHandler := handlers.NewIPBlockHandler(ipAccess)
+
+	adminIPBlocks := adminAccount.Group("/ip-blocks")
+	adminIPBlocks.Use(middleware.RequirePermission(auth.PermissionSecurityManage))
+	adminIPBlocks.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminIPBlocks.GET("", ipBlockHandler.ListBlocks)
+		adminIPBlocks.POST("",
+			middleware.ValidateContentTypeMiddleware(),
+			ipBlockHandler.CreateBlock,
+		)
+		adminIPBlocks.DELETE("/:id", ipBlockHandler.DeleteBlock)
+	}
+}
+
+func setupProjectRoutes(api *gin.RouterGroup, adminProtected *gin.RouterGroup, cfg *config.Config) {
+	projectRepo := repository.NewProjectRepository(cfg.DB)
+	projectHandler := handlers.NewProjectHandler(projectRepo)
+
+	projectsGroup := api.Group("/projects")
+	{
+		projectsGroup.GET("",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+			projectHandler.ListProjects,
+		)
+		projectsGroup.GET("/:slug",
 			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
-			assetHandler.GetHeroBanner,
+			projectHandler.GetProject,
+		)
+	}
+
+	adminProjects := adminProtected.Group("/projects")
+	adminProjects.Use(middleware.RequirePermission(auth.PermissionContentWrite))
+	adminProjects.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		adminProjects.POST("",
+			middleware.ValidateContentTypeMiddleware(),
+			projectHandler.CreateProject,
 		)
+		adminProjects.PUT("/:id",
+			middleware.ValidateContentTypeMiddleware(),
+			projectHandler.UpdateProject,
+		)
+		adminProjects.DELETE("/:id", projectHandler.DeleteProject)
+	}
+}
+
+func setupBlogRoutes(api *gin.RouterGroup, adminProtected *gin.RouterGroup, cfg *config.Config) {
+	blogService := services.NewBlogService(repository.NewBlogRepository(cfg.DB))
+	blogHandler := handlers.NewBlogHandler(blogService)
+
+	blogGroup := api.Group("/blog")
+	{
+		blogGroup.GET("",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+			blogHandler.ListPublishedPosts,
+		)
+		blogGroup.GET("/:slug",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+			blogHandler.GetPublishedPost,
+		)
+	}
+
+	adminBlog := adminProtected.Group("/blog")
+	adminBlog.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		canRead := middleware.RequirePermission(auth.PermissionContentRead)
+		canWrite := middleware.RequirePermission(auth.PermissionContentWrite)
+
+		adminBlog.GET("", canRead, blogHandler.ListPosts)
+		adminBlog.GET("/:id", canRead, blogHandler.GetPost)
+		adminBlog.POST("",
+			canWrite,
+			middleware.ValidateContentTypeMiddleware(),
+			blogHandler.CreatePost,
+		)
+		adminBlog.PUT("/:id",
+			canWrite,
+			middleware.ValidateContentTypeMiddleware(),
+			blogHandler.UpdatePost,
+		)
+		adminBlog.POST("/preview",
+			canRead,
+			middleware.ValidateContentTypeMiddleware(),
+			blogHandler.PreviewPost,
+		)
+		adminBlog.POST("/:id/schedule",
+			canWrite,
+			middleware.ValidateContentTypeMiddleware(),
+			blogHandler.SchedulePost,
+		)
+		adminBlog.POST("/:id/publish", canWrite, blogHandler.PublishPost)
+		adminBlog.POST("/:id/unpublish", canWrite, blogHandler.UnpublishPost)
+		adminBlog.DELETE("/:id", canWrite, blogHandler.DeletePost)
+	}
+}
+
+func setupContactRoutes(api *gin.RouterGroup, adminProtected *gin.RouterGroup, cfg *config.Config, notifier *notify.Notifier) {
+	formTokens, err := utils.NewFormTokenSigner(cfg.Contact.FormSecret)
+	if err != nil {
+		fmt.Printf("Warning: contact form disabled, failed to create form token key: %v\n", err)
+		return
+	}
+
+	contactHandler := handlers.NewContactHandler(repository.NewContactRepository(cfg.DB), notifier, formTokens)
+
+	api.GET("/contact/token",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+		contactHandler.GetFormToken,
+	)
+	api.POST("/contact",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitContact, cfg.RateLimit),
+		middleware.ValidateContentTypeMiddleware(),
+		contactHandler.SubmitContactMessage,
+	)
+
+	adminContact := adminProtected.Group("/contact")
+	adminContact.Use(middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit))
+	{
+		canRead := middleware.RequirePermission(auth.PermissionMessagesRead)
+		canWrite := middleware.RequirePermission(auth.PermissionMessagesWrite)
+
+		adminContact.GET("", canRead, contactHandler.ListContactMessages)
+		adminContact.GET("/:id", canRead, contactHandler.GetContactMessage)
+		adminContact.POST("/:id/handled", canWrite, contactHandler.MarkContactMessageHandled)
+		adminContact.DELETE("/:id", canWrite, contactHandler.DeleteContactMessage)
+	}
+}
+
+func setupMediaRoutes(api *gin.RouterGroup, adminProtected *gin.RouterGroup, cfg *config.Config, assetService *services.AssetService) {
+	mediaService := services.NewMediaService(assetService, repository.NewMediaRepository(cfg.DB))
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+
+	api.GET("/media/:key",
+		middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+		mediaHandler.ServeMedia,
+	)
+
+	adminMedia := adminProtected.Group("/media")
+	{
+		canRead := middleware.RequirePermission(auth.PermissionAssetsRead)
+		canWrite := middleware.RequirePermission(auth.PermissionAssetsWrite)
+
+		adminMedia.GET("",
+			canRead,
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+			mediaHandler.ListMedia,
+		)
+		adminMedia.POST("",
+			canWrite,
+			middleware.FileUploadSizeLimitMiddleware(middleware.GetFileUploadSizeLimit()),
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitUpload, cfg.RateLimit),
+			mediaHandler.UploadMedia,
+		)
+		adminMedia.GET("/:id",
+			canRead,
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+			mediaHandler.GetMedia,
+		)
+		adminMedia.DELETE("/:id",
+			canWrite,
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+			mediaHandler.DeleteMedia,
+		)
+	}
+}
+
+func setupAssetRoutes(api *gin.RouterGroup, cfg *config.Config, authService *auth.AuthService, assetService *services.AssetService, sessionService *services.SessionService, apiKeyService *services.APIKeyService) {
+	assetHandler := handlers.NewAssetHandler(assetService)
+
+	assetsGroup := api.Group("/assets")
+	{
 		assetsGroup.GET("/info",
 			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
 			assetHandler.GetAssetInfo,
 		)
+		assetsGroup.GET("/:slot",
+			middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitPublic, cfg.RateLimit),
+			assetHandler.GetAsset,
+		)
 	}
 
 	adminAssetGroup := api.Group("/admin/assets")
 
-	if cfg.DB != nil {
-		adminRepo := repository.NewAdminRepository(cfg.DB)
-		authService := auth.NewAuthService(os.Getenv("JWT_SECRET"), 1*time.Hour)
-
+	if sessionService != nil {
 		protected := adminAssetGroup.Group("")
-		protected.Use(middleware.AuthMiddleware(authService, adminRepo))
+		protected.Use(middleware.AuthMiddleware(authService, sessionService, apiKeyService))
+		protected.Use(middleware.APIKeyRateLimitMiddleware(cfg.RateLimit))
+		protected.Use(middleware.RequirePermission(auth.PermissionAssetsWrite))
 		protected.Use(middleware.FileUploadSizeLimitMiddleware(middleware.GetFileUploadSizeLimit()))
 		{
-			protected.POST("/hero-banner",
+			protected.POST("/:slot",
 				middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitUpload, cfg.RateLimit),
-				assetHandler.UploadHeroBanner,
+				assetHandler.UploadAsset,
+			)
+			protected.DELETE("/:slot",
+				middleware.RateLimitMiddlewareWithConfig(middleware.RateLimitAdmin, cfg.RateLimit),
+				assetHandler.DeleteAsset,
 			)
 		}
 	}
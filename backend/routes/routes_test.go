@@ -0,0 +1,174 @@
+package routes
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/services"
+	"github.com/gin-gonic/gin"
+)
+
+// testRouter is the full route tree wired to the test database
+type testRouter struct {
+	engine      *gin.Engine
+	db          *sql.DB
+	authService *auth.AuthService
+}
+
+func newTestRouter(t *testing.T) *testRouter {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := dbtest.Open(t)
+
+	keys, err := auth.LoadKeySet("routes-test-secret", "", nil)
+	if err != nil {
+		t.Fatalf("failed to load signing keys: %v", err)
+	}
+	authService := auth.NewAuthService(keys, auth.NewRevocationList(repository.NewRevokedTokenRepository(db)), time.Hour)
+
+	cfg := &config.Config{
+		DB:              db,
+		RateLimit:       config.LoadRateLimitConfig(),
+		SecurityHeaders: &config.SecurityHeadersConfig{},
+		Images:          config.LoadImageConfig(),
+		Storage:         &config.StorageConfig{Driver: "local", Bucket: "test", LocalDir: t.TempDir()},
+		MFA:             config.LoadMFAConfig(),
+		LoginProtection: config.LoadLoginProtectionConfig(),
+		Audit:           config.LoadAuditConfig(),
+		IPAccess:        &config.IPAccessConfig{},
+		Contact:         config.LoadContactConfig(),
+	}
+
+	engine := gin.New()
+	SetupRoutes(engine, cfg, authService)
+
+	return &testRouter{engine: engine, db: db, authService: authService}
+}
+
+// createAdmin adds an admin with the given role, created by a fresh owner
+func (tr *testRouter) createAdmin(t *testing.T, role models.AdminRole) *models.Admin {
+	t.Helper()
+
+	adminRepo := repository.NewAdminRepository(tr.db)
+	owner, err := adminRepo.CreateAdmin(dbtest.UniqueName("routes-owner-"), "unused", "")
+	if err != nil {
+		t.Fatalf("failed to create owner: %v", err)
+	}
+	if role == models.AdminRoleOwner {
+		return owner
+	}
+
+	admin, err := adminRepo.CreateAdminBy(dbtest.UniqueName("routes-"+string(role)+"-"), "unused", role, owner.ID)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", role, err)
+	}
+	return admin
+}
+
+// signIn starts a session for the admin and returns its access token
+func (tr *testRouter) signIn(t *testing.T, admin *models.Admin) string {
+	t.Helper()
+
+	sessionService := services.NewSessionService(tr.authService, repository.NewAdminRepository(tr.db), repository.NewSessionRepository(tr.db))
+	session, err := sessionService.StartSession(admin, "198.51.100.7", "routes-test")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	return session.Tokens.AccessToken
+}
+
+// do sends a request with the given Authorization header and an empty JSON body for writes
+func (tr *testRouter) do(method, path, authorization string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if method == http.MethodGet {
+		req = httptest.NewRequest(method, path, nil)
+	} else {
+		req = httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", authorization)
+
+	recorder := httptest.NewRecorder()
+	tr.engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestViewerIsRefusedWriteRoutes(t *testing.T) {
+	tr := newTestRouter(t)
+	token := "Bearer " + tr.signIn(t, tr.createAdmin(t, models.AdminRoleViewer))
+
+	if recorder := tr.do(http.MethodGet, "/api/admin/blog", token); recorder.Code != http.StatusOK {
+		t.Fatalf("GET /api/admin/blog: status %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	writes := []struct{ method, path string }{
+		{http.MethodPost, "/api/admin/projects"},
+		{http.MethodPost, "/api/admin/blog"},
+		{http.MethodDelete, "/api/admin/blog/1"},
+		{http.MethodGet, "/api/admin/users"},
+		{http.MethodGet, "/api/admin/audit-log"},
+	}
+	for _, route := range writes {
+		if recorder := tr.do(route.method, route.path, token); recorder.Code != http.StatusForbidden {
+			t.Errorf("%s %s: status %d, want %d", route.method, route.path, recorder.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestTokenIsNarrowedAfterRoleDowngrade(t *testing.T) {
+	tr := newTestRouter(t)
+	editor := tr.createAdmin(t, models.AdminRoleEditor)
+	token := "Bearer " + tr.signIn(t, editor)
+
+	// The empty body fails validation, which shows the permission check was passed
+	if recorder := tr.do(http.MethodPost, "/api/admin/projects", token); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("before the downgrade: status %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+
+	if _, err := repository.NewAdminRepository(tr.db).UpdateAdminRole(editor.ID, models.AdminRoleViewer); err != nil {
+		t.Fatalf("failed to downgrade editor: %v", err)
+	}
+
+	if recorder := tr.do(http.MethodPost, "/api/admin/projects", token); recorder.Code != http.StatusForbidden {
+		t.Errorf("after the downgrade: status %d, want %d", recorder.Code, http.StatusForbidden)
+	}
+}
+
+func TestAPIKeyIsRefusedAccountRoutes(t *testing.T) {
+	tr := newTestRouter(t)
+	editor := tr.createAdmin(t, models.AdminRoleEditor)
+
+	apiKeyService := services.NewAPIKeyService(repository.NewAPIKeyRepository(tr.db), repository.NewAdminRepository(tr.db))
+	_, rawKey, err := apiKeyService.CreateKey(editor, "deploy", []string{auth.PermissionContentRead, auth.PermissionContentWrite}, nil)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	authorization := "ApiKey " + rawKey
+
+	if recorder := tr.do(http.MethodGet, "/api/admin/blog", authorization); recorder.Code != http.StatusOK {
+		t.Fatalf("GET /api/admin/blog: status %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	accountRoutes := []struct{ method, path string }{
+		{http.MethodGet, "/api/admin/sessions"},
+		{http.MethodGet, "/api/admin/api-keys"},
+		{http.MethodPost, "/api/admin/api-keys"},
+		{http.MethodPost, "/api/admin/password"},
+		{http.MethodPost, "/api/admin/logout"},
+	}
+	for _, route := range accountRoutes {
+		if recorder := tr.do(route.method, route.path, authorization); recorder.Code != http.StatusForbidden {
+			t.Errorf("%s %s: status %d, want %d", route.method, route.path, recorder.Code, http.StatusForbidden)
+		}
+	}
+}
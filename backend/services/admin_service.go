@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/config"
 	"github.com/Wildcard209/portfolio-webapplication/database"
+	"github.com/Wildcard209/portfolio-webapplication/models"
 	"github.com/Wildcard209/portfolio-webapplication/repository"
 )
 
@@ -17,14 +19,36 @@ type AdminService struct {
 	authService      *auth.AuthService
 	adminRepo        *repository.AdminRepository
 	loginAttemptRepo *repository.LoginAttemptRepository
+	resetRepo        *repository.PasswordResetRepository
+	sessionRepo      *repository.SessionRepository
+	webauthnRepo     *repository.WebAuthnRepository
+	apiKeyRepo       *repository.APIKeyRepository
+	ipBlockRepo      *repository.IPBlockRepository
+	passwordService  *PasswordService
+	loginProtection  *LoginProtectionService
+	auditService     *AuditService
+	auditRetention   time.Duration
 }
 
-func NewAdminService(db *sql.DB, authService *auth.AuthService) *AdminService {
+func NewAdminService(db *sql.DB, authService *auth.AuthService, loginProtectionConfig *config.LoginProtectionConfig, auditConfig *config.AuditConfig) *AdminService {
+	adminRepo := repository.NewAdminRepository(db)
+	resetRepo := repository.NewPasswordResetRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+
 	return &AdminService{
 		db:               db,
 		authService:      authService,
-		adminRepo:        repository.NewAdminRepository(db),
+		adminRepo:        adminRepo,
 		loginAttemptRepo: repository.NewLoginAttemptRepository(db),
+		resetRepo:        resetRepo,
+		sessionRepo:      sessionRepo,
+		webauthnRepo:     repository.NewWebAuthnRepository(db),
+		apiKeyRepo:       repository.NewAPIKeyRepository(db),
+		ipBlockRepo:      repository.NewIPBlockRepository(db),
+		passwordService:  NewPasswordService(authService, adminRepo, resetRepo, NewSessionService(authService, adminRepo, sessionRepo)),
+		loginProtection:  NewLoginProtectionService(repository.NewLoginLockoutRepository(db), loginProtectionConfig),
+		auditService:     NewAuditService(repository.NewAuditLogRepository(db)),
+		auditRetention:   auditConfig.Retention,
 	}
 }
 
@@ -47,10 +71,17 @@ func (s *AdminService) InitializeAdminSystem() error {
 		}
 	} else {
 		log.Printf("Found %d admin user(s) in the system", adminCount)
+		if err := s.issueLegacyResetTokens(); err != nil {
+			log.Printf("Warning: Failed to check for legacy password hashes: %v", err)
+		}
 	}
 
-	if err := s.adminRepo.CleanupExpiredTokens(); err != nil {
-		log.Printf("Warning: Failed to cleanup expired tokens: %v", err)
+	if err := s.sessionRepo.CleanupExpiredSessions(time.Now()); err != nil {
+		log.Printf("Warning: Failed to cleanup expired sessions: %v", err)
+	}
+
+	if err := s.authService.LoadRevokedTokens(); err != nil {
+		log.Printf("Warning: Failed to load revoked tokens: %v", err)
 	}
 
 	cutoffTime := time.Now().AddDate(0, 0, -30)
@@ -84,6 +115,38 @@ func (s *AdminService) createDefaultAdmin() error {
 	return nil
 }
 
+// issueLegacyResetTokens prints reset tokens for legacy-hash admins when no active admin
+// can log in, since nobody would otherwise be able to issue one
+func (s *AdminService) issueLegacyResetTokens() error {
+	admins, err := s.adminRepo.ListAdmins()
+	if err != nil {
+		return err
+	}
+
+	var legacyAdmins []models.Admin
+	for _, admin := range admins {
+		if !admin.IsActive {
+			continue
+		}
+		if admin.HashVersion != 1 {
+			return nil
+		}
+		legacyAdmins = append(legacyAdmins, admin)
+	}
+
+	for _, admin := range legacyAdmins {
+		token, expiresAt, err := s.passwordService.IssueResetToken(admin.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Admin %q uses a legacy password hash and cannot log in. Reset the password via POST /api/admin/password/reset with this one-time token (valid until %s): %s",
+			admin.Username, expiresAt.Format(time.RFC3339), token)
+	}
+
+	return nil
+}
+
 func (s *AdminService) StartMaintenanceTasks() {
 	ticker := time.NewTicker(1 * time.Hour)
 
@@ -97,14 +160,48 @@ func (s *AdminService) StartMaintenanceTasks() {
 }
 
 func (s *AdminService) runMaintenanceTasks() {
-	if err := s.adminRepo.CleanupExpiredTokens(); err != nil {
-		log.Printf("Maintenance: Failed to cleanup expired tokens: %v", err)
+	if err := s.sessionRepo.CleanupExpiredSessions(time.Now()); err != nil {
+		log.Printf("Maintenance: Failed to cleanup expired sessions: %v", err)
 	}
 
 	cutoffTime := time.Now().AddDate(0, 0, -7)
 	if err := s.loginAttemptRepo.CleanupOldLoginAttempts(cutoffTime); err != nil {
 		log.Printf("Maintenance: Failed to cleanup old login attempts: %v", err)
 	}
+
+	if err := s.resetRepo.CleanupResetTokens(time.Now().AddDate(0, 0, -1)); err != nil {
+		log.Printf("Maintenance: Failed to cleanup password reset tokens: %v", err)
+	}
+
+	if err := s.webauthnRepo.CleanupCeremonies(time.Now()); err != nil {
+		log.Printf("Maintenance: Failed to cleanup WebAuthn ceremonies: %v", err)
+	}
+
+	// Revoked and expired keys are kept for a while so admins can see why a client stopped working
+	if err := s.apiKeyRepo.CleanupAPIKeys(time.Now().AddDate(0, 0, -30)); err != nil {
+		log.Printf("Maintenance: Failed to cleanup API keys: %v", err)
+	}
+
+	if err := s.ipBlockRepo.CleanupIPBlocks(time.Now()); err != nil {
+		log.Printf("Maintenance: Failed to cleanup expired IP blocks: %v", err)
+	}
+
+	if err := s.loginProtection.Cleanup(); err != nil {
+		log.Printf("Maintenance: Failed to cleanup login lockouts: %v", err)
+	}
+
+	if err := s.auditService.Cleanup(s.auditRetention); err != nil {
+		log.Printf("Maintenance: Failed to cleanup audit log: %v", err)
+	}
+
+	if err := s.authService.PruneRevokedTokens(); err != nil {
+		log.Printf("Maintenance: Failed to prune revoked tokens: %v", err)
+	}
+
+	// Picks up tokens revoked by other instances
+	if err := s.authService.LoadRevokedTokens(); err != nil {
+		log.Printf("Maintenance: Failed to reload revoked tokens: %v", err)
+	}
 }
 
 func (s *AdminService) GetRepositories() (*repository.AdminRepository, *repository.LoginAttemptRepository) {
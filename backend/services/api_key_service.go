@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+var (
+	ErrAPIKeyInvalid       = errors.New("API key is invalid, expired or revoked")
+	ErrAPIKeyScopeInvalid  = errors.New("API key scope is not allowed")
+	ErrAPIKeyExpiryInvalid = errors.New("API key expiry must be in the future")
+)
+
+const (
+	// APIKeyPrefix marks a string as one of our API keys, which helps secret scanners find leaked keys
+	APIKeyPrefix = "pwa_"
+	apiKeySize   = 32
+	// apiKeyDisplayPrefixLength is how much of the key is stored in clear to identify it
+	apiKeyDisplayPrefixLength = len(APIKeyPrefix) + 8
+	// apiKeyTouchInterval limits how often authenticated requests update last_used_at
+	apiKeyTouchInterval = time.Minute
+)
+
+// APIKeyPrincipal is the admin an API key acts for and the permissions it has on their behalf
+type APIKeyPrincipal struct {
+	Admin       *models.Admin
+	Key         *models.APIKey
+	Permissions []string
+}
+
+type APIKeyService struct {
+	apiKeyRepo *repository.APIKeyRepository
+	adminRepo  *repository.AdminRepository
+}
+
+func NewAPIKeyService(apiKeyRepo *repository.APIKeyRepository, adminRepo *repository.AdminRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		adminRepo:  adminRepo,
+	}
+}
+
+// CreateKey mints a key for the admin. The returned key string is not stored and cannot be
+// retrieved again. Scopes must be API key scopes the admin's role grants.
+func (s *APIKeyService) CreateKey(admin *models.Admin, name string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error) {
+	granted := auth.PermissionsForRole(admin.Role)
+	for _, scope := range scopes {
+		if !auth.HasPermission(auth.APIKeyScopes, scope) || !auth.HasPermission(granted, scope) {
+			return nil, "", fmt.Errorf("%w: %s", ErrAPIKeyScopeInvalid, scope)
+		}
+	}
+
+	key := &models.APIKey{
+		AdminID: admin.ID,
+		Name:    name,
+		Scopes:  dedupeScopes(scopes),
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(time.Now()) {
+			return nil, "", ErrAPIKeyExpiryInvalid
+		}
+		key.ExpiresAt = models.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	secret, err := generateToken(apiKeySize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rawKey := APIKeyPrefix + secret
+	key.KeyPrefix = rawKey[:apiKeyDisplayPrefixLength]
+	key.KeyHash = hashToken(rawKey)
+
+	created, err := s.apiKeyRepo.CreateAPIKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return created, rawKey, nil
+}
+
+// Authenticate resolves a raw key to its admin. The key's permissions are narrowed to what the
+// admin's current role grants, so a demotion also limits their keys.
+func (s *APIKeyService) Authenticate(rawKey, ipAddress string) (*APIKeyPrincipal, error) {
+	if !strings.HasPrefix(rawKey, APIKeyPrefix) {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	key, err := s.apiKeyRepo.GetActiveAPIKeyByHash(hashToken(rawKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	admin, err := s.adminRepo.GetAdminByID(key.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if admin == nil || !admin.IsActive {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	granted := auth.PermissionsForRole(admin.Role)
+	permissions := []string{}
+	for _, scope := range key.Scopes {
+		if auth.HasPermission(granted, scope) && auth.HasPermission(auth.APIKeyScopes, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	if !key.LastUsedAt.Valid || time.Since(key.LastUsedAt.Time) > apiKeyTouchInterval {
+		go func() {
+			if err := s.apiKeyRepo.TouchAPIKey(key.ID, ipAddress); err != nil {
+				log.Printf("Failed to update API key activity: %v", err)
+			}
+		}()
+	}
+
+	return &APIKeyPrincipal{
+		Admin:       admin,
+		Key:         key,
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *APIKeyService) ListKeys(adminID int) ([]models.APIKey, error) {
+	return s.apiKeyRepo.ListAPIKeys(adminID)
+}
+
+// RevokeKey revokes one of the admin's keys. It returns nil when the admin has no such key.
+func (s *APIKeyService) RevokeKey(id, adminID int) (*models.APIKey, error) {
+	return s.apiKeyRepo.RevokeAPIKey(id, adminID)
+}
+
+// RevokeAllKeys revokes every key of the admin and returns how many were revoked
+func (s *APIKeyService) RevokeAllKeys(adminID int) (int64, error) {
+	return s.apiKeyRepo.RevokeAllAPIKeys(adminID)
+}
+
+func dedupeScopes(scopes []string) []string {
+	unique := []string{}
+	for _, scope := range scopes {
+		if !auth.HasPermission(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+func TestCreateKeyRejectsScopesBeyondRole(t *testing.T) {
+	// Scopes are checked before anything is stored
+	s := NewAPIKeyService(nil, nil)
+
+	tests := []struct {
+		role  models.AdminRole
+		scope string
+	}{
+		{models.AdminRoleViewer, auth.PermissionContentWrite},
+		{models.AdminRoleViewer, auth.PermissionAssetsWrite},
+		{models.AdminRoleEditor, auth.PermissionUsersManage},
+		{models.AdminRoleOwner, auth.PermissionUsersManage},
+		{models.AdminRoleOwner, auth.PermissionSecurityManage},
+		{models.AdminRoleOwner, "everything:manage"},
+	}
+
+	for _, tt := range tests {
+		admin := &models.Admin{ID: 1, Role: tt.role}
+		scopes := []string{auth.PermissionContentRead, tt.scope}
+
+		if _, _, err := s.CreateKey(admin, "test", scopes, nil); !errors.Is(err, ErrAPIKeyScopeInvalid) {
+			t.Errorf("%s asking for %s: got error %v, want ErrAPIKeyScopeInvalid", tt.role, tt.scope, err)
+		}
+	}
+}
+
+func TestAuthenticateCapsScopesToCurrentRole(t *testing.T) {
+	db := dbtest.Open(t)
+	adminRepo := repository.NewAdminRepository(db)
+	s := NewAPIKeyService(repository.NewAPIKeyRepository(db), adminRepo)
+
+	editor := createTestAdmin(t, db, models.AdminRoleEditor)
+	_, rawKey, err := s.CreateKey(editor, "deploy", []string{auth.PermissionContentRead, auth.PermissionContentWrite}, nil)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	principal, err := s.Authenticate(rawKey, "198.51.100.7")
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if want := []string{auth.PermissionContentRead, auth.PermissionContentWrite}; !slices.Equal(principal.Permissions, want) {
+		t.Errorf("permissions = %v, want %v", principal.Permissions, want)
+	}
+
+	if _, err := adminRepo.UpdateAdminRole(editor.ID, models.AdminRoleViewer); err != nil {
+		t.Fatalf("failed to downgrade editor: %v", err)
+	}
+
+	principal, err = s.Authenticate(rawKey, "198.51.100.7")
+	if err != nil {
+		t.Fatalf("failed to authenticate after the downgrade: %v", err)
+	}
+	if want := []string{auth.PermissionContentRead}; !slices.Equal(principal.Permissions, want) {
+		t.Errorf("permissions after the downgrade = %v, want %v", principal.Permissions, want)
+	}
+}
+
+func TestAuthenticateRejectsUnusableKeys(t *testing.T) {
+	db := dbtest.Open(t)
+	adminRepo := repository.NewAdminRepository(db)
+	s := NewAPIKeyService(repository.NewAPIKeyRepository(db), adminRepo)
+
+	newKey := func(admin *models.Admin) (*models.APIKey, string) {
+		t.Helper()
+		key, rawKey, err := s.CreateKey(admin, "test", []string{auth.PermissionContentRead}, nil)
+		if err != nil {
+			t.Fatalf("failed to create key: %v", err)
+		}
+		return key, rawKey
+	}
+
+	t.Run("revoked", func(t *testing.T) {
+		admin := createTestAdmin(t, db, models.AdminRoleEditor)
+		key, rawKey := newKey(admin)
+		if revoked, err := s.RevokeKey(key.ID, admin.ID); err != nil || revoked == nil {
+			t.Fatalf("failed to revoke key: %v", err)
+		}
+
+		if _, err := s.Authenticate(rawKey, "198.51.100.7"); !errors.Is(err, ErrAPIKeyInvalid) {
+			t.Errorf("got error %v, want ErrAPIKeyInvalid", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		admin := createTestAdmin(t, db, models.AdminRoleEditor)
+		key, rawKey := newKey(admin)
+		if _, err := db.Exec("UPDATE api_keys SET expires_at = $1 WHERE id = $2", time.Now().Add(-time.Minute), key.ID); err != nil {
+			t.Fatalf("failed to expire key: %v", err)
+		}
+
+		if _, err := s.Authenticate(rawKey, "198.51.100.7"); !errors.Is(err, ErrAPIKeyInvalid) {
+			t.Errorf("got error %v, want ErrAPIKeyInvalid", err)
+		}
+	})
+
+	t.Run("owner disabled", func(t *testing.T) {
+		admin := createTestAdmin(t, db, models.AdminRoleEditor)
+		_, rawKey := newKey(admin)
+		if _, err := adminRepo.DisableAdmin(admin.ID); err != nil {
+			t.Fatalf("failed to disable admin: %v", err)
+		}
+
+		if _, err := s.Authenticate(rawKey, "198.51.100.7"); !errors.Is(err, ErrAPIKeyInvalid) {
+			t.Errorf("got error %v, want ErrAPIKeyInvalid", err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		for _, rawKey := range []string{APIKeyPrefix + "unknown", "not-an-api-key"} {
+			if _, err := s.Authenticate(rawKey, "198.51.100.7"); !errors.Is(err, ErrAPIKeyInvalid) {
+				t.Errorf("%q: got error %v, want ErrAPIKeyInvalid", rawKey, err)
+			}
+		}
+	})
+}
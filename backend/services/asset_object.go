@@ -0,0 +1,38 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// CachePolicy describes how long clients may reuse a delivered asset before revalidating
+type CachePolicy struct {
+	MaxAge    time.Duration
+	Immutable bool
+}
+
+// CacheControl renders the policy as a Cache-Control header value. A zero MaxAge still
+// allows caching but requires revalidation, which is cheap thanks to ETags.
+func (p CachePolicy) CacheControl() string {
+	if p.MaxAge <= 0 {
+		return "public, no-cache"
+	}
+
+	value := fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+	if p.Immutable {
+		value += ", immutable"
+	}
+	return value
+}
+
+// AssetObject is an open, seekable handle on a stored file so that it can be streamed
+// to the client without buffering. Callers must Close it.
+type AssetObject struct {
+	io.ReadSeekCloser
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	CachePolicy  CachePolicy
+}
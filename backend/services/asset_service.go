@@ -1,149 +1,270 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
-	"strings"
+	"slices"
 
-	"github.com/minio/minio-go/v7"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/storage"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+var (
+	ErrUnknownAssetSlot = errors.New("unknown asset slot")
+	ErrAssetNotFound    = errors.New("asset not found")
+	ErrInvalidImage     = errors.New("image data could not be processed")
 )
 
 type AssetService struct {
-	minioClient *minio.Client
-	bucketName  string
+	store          storage.ObjectStore
+	imageProcessor *utils.ImageProcessor
+}
+
+// NewAssetService creates the service; a nil imageProcessor disables variant generation
+func NewAssetService(store storage.ObjectStore, imageProcessor *utils.ImageProcessor) *AssetService {
+	return &AssetService{
+		store:          store,
+		imageProcessor: imageProcessor,
+	}
 }
 
-func NewAssetService(minioClient *minio.Client) *AssetService {
-	service := &AssetService{
-		minioClient: minioClient,
-		bucketName:  "portfolio-assets",
+// GetAsset opens the file currently stored in a slot, or the closest matching resized
+// variant when the request asks for a specific width
+func (s *AssetService) GetAsset(slotName string, request AssetVariantRequest) (*AssetObject, error) {
+	slot, ok := GetAssetSlot(slotName)
+	if !ok {
+		return nil, ErrUnknownAssetSlot
+	}
+
+	objectInfo, err := s.statObject(slot.Name)
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, fmt.Errorf("failed to get object info: %w", err)
+	}
+
+	variants := decodeAssetVariants(objectInfo.Metadata[assetVariantsMetadataKey])
+	if variant, ok := selectAssetVariant(variants, request); ok {
+		object, err := s.openObject(variant.objectName(slot.Name))
+		if err == nil {
+			object.CachePolicy = slot.CachePolicy
+			return object, nil
+		}
+		// Variants are replaced before the original on upload, so fall back to the original
+		if !isObjectNotFound(err) {
+			log.Printf("Failed to get variant of asset %s: %v", slot.Name, err)
+		}
 	}
 
-	// Ensure bucket exists
-	if err := service.ensureBucketExists(); err != nil {
-		log.Printf("Warning: Failed to ensure bucket exists: %v", err)
+	object, err := s.openObject(slot.Name)
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, fmt.Errorf("failed to get asset %s: %w", slot.Name, err)
 	}
 
-	return service
+	object.CachePolicy = slot.CachePolicy
+	return object, nil
 }
 
-func (s *AssetService) ensureBucketExists() error {
-	ctx := context.Background()
+// UploadAsset replaces the file stored in a slot. The file must already have been
+// validated against the slot's allowed types and size limit.
+func (s *AssetService) UploadAsset(slotName string, file multipart.File, header *multipart.FileHeader) error {
+	slot, ok := GetAssetSlot(slotName)
+	if !ok {
+		return ErrUnknownAssetSlot
+	}
 
-	exists, err := s.minioClient.BucketExists(ctx, s.bucketName)
+	data, err := io.ReadAll(file)
 	if err != nil {
-		return fmt.Errorf("failed to check if bucket exists: %w", err)
+		return fmt.Errorf("failed to read upload: %w", err)
 	}
 
-	if !exists {
-		err = s.minioClient.MakeBucket(ctx, s.bucketName, minio.MakeBucketOptions{})
+	// The stored type is what the asset is served as, so it comes from the file's contents
+	// rather than anything the client sent
+	contentType, err := utils.DetectFileType(data)
+	if err != nil || !slices.Contains(slot.AllowedTypes, contentType) {
+		return fmt.Errorf("%w: unexpected file type", ErrInvalidImage)
+	}
+
+	// Uploads are public, so location and device details must never reach the bucket
+	data, err = utils.StripImageMetadata(data, slot.Metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	var variants []utils.ImageVariant
+	if slot.Variants && s.imageProcessor != nil && s.imageProcessor.CanResize(contentType) {
+		variants, err = s.imageProcessor.GenerateVariants(data)
+		if errors.Is(err, utils.ErrImageTooLarge) {
+			return fmt.Errorf("%w: %v", ErrInvalidImage, err)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create bucket: %w", err)
+			// The original is still usable, it just won't have smaller renditions
+			log.Printf("Failed to generate variants for asset %s: %v", slot.Name, err)
+			variants = nil
 		}
-		log.Printf("Created bucket: %s", s.bucketName)
 	}
 
+	if err := s.removeVariants(slot.Name); err != nil {
+		return fmt.Errorf("failed to remove old variants of asset %s: %w", slot.Name, err)
+	}
+
+	for _, variant := range variants {
+		objectName := assetVariant{Width: variant.Width, Format: variant.Format}.objectName(slot.Name)
+		if err := s.putObject(objectName, bytes.NewReader(variant.Data), int64(len(variant.Data)), variant.ContentType, nil); err != nil {
+			return fmt.Errorf("failed to upload variant of asset %s: %w", slot.Name, err)
+		}
+	}
+
+	metadata := map[string]string{}
+	if len(variants) > 0 {
+		metadata[assetVariantsMetadataKey] = encodeAssetVariants(variants)
+	}
+
+	if err := s.putObject(slot.Name, bytes.NewReader(data), int64(len(data)), contentType, metadata); err != nil {
+		return fmt.Errorf("failed to upload asset %s: %w", slot.Name, err)
+	}
+
+	log.Printf("Successfully uploaded asset %s: %s (size: %d bytes, type: %s, variants: %d)",
+		slot.Name, header.Filename, len(data), contentType, len(variants))
 	return nil
 }
 
-// GetHeroBanner gets the current hero banner image
-func (s *AssetService) GetHeroBanner() ([]byte, string, error) {
-	ctx := context.Background()
-	objectName := "hero-banner"
-
-	// Try to get the object
-	object, err := s.minioClient.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get hero banner: %w", err)
+// DeleteAsset empties a slot
+func (s *AssetService) DeleteAsset(slotName string) error {
+	slot, ok := GetAssetSlot(slotName)
+	if !ok {
+		return ErrUnknownAssetSlot
 	}
-	defer object.Close()
 
-	// Read the object data
-	data, err := io.ReadAll(object)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to read hero banner data: %w", err)
+	// RemoveObject succeeds for missing keys, so check existence to give callers a 404
+	if _, err := s.statObject(slot.Name); err != nil {
+		if isObjectNotFound(err) {
+			return ErrAssetNotFound
+		}
+		return fmt.Errorf("failed to get object info: %w", err)
 	}
 
-	// Get object info to determine content type
-	objectInfo, err := object.Stat()
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get object info: %w", err)
+	if err := s.removeObject(slot.Name); err != nil {
+		return fmt.Errorf("failed to delete asset %s: %w", slot.Name, err)
 	}
 
-	contentType := objectInfo.ContentType
-	if contentType == "" {
-		// Try to determine content type from object name or data
-		contentType = "application/octet-stream"
+	if err := s.removeVariants(slot.Name); err != nil {
+		log.Printf("Failed to delete variants of asset %s: %v", slot.Name, err)
 	}
 
-	return data, contentType, nil
+	log.Printf("Successfully deleted asset %s", slot.Name)
+	return nil
 }
 
-// UploadHeroBanner uploads a new hero banner image
-func (s *AssetService) UploadHeroBanner(file multipart.File, header *multipart.FileHeader) error {
-	ctx := context.Background()
-	objectName := "hero-banner"
+// GetAssetInfo reports availability and metadata for every registered slot
+func (s *AssetService) GetAssetInfo() []models.AssetSlotInfo {
+	slots := AssetSlots()
+	infos := make([]models.AssetSlotInfo, 0, len(slots))
 
-	// Determine content type
-	contentType := header.Header.Get("Content-Type")
-	if contentType == "" {
-		// Try to determine from filename
-		filename := strings.ToLower(header.Filename)
-		if strings.HasSuffix(filename, ".jpg") || strings.HasSuffix(filename, ".jpeg") {
-			contentType = "image/jpeg"
-		} else if strings.HasSuffix(filename, ".png") {
-			contentType = "image/png"
-		} else if strings.HasSuffix(filename, ".gif") {
-			contentType = "image/gif"
-		} else if strings.HasSuffix(filename, ".webp") {
-			contentType = "image/webp"
-		} else {
-			contentType = "application/octet-stream"
+	for _, slot := range slots {
+		info := models.AssetSlotInfo{
+			Slot:         slot.Name,
+			Description:  slot.Description,
+			URL:          s.GetAssetURL(slot.Name),
+			AllowedTypes: slot.AllowedTypes,
+			MaxSize:      slot.MaxSize,
+			Variants:     []models.AssetVariant{},
 		}
+
+		objectInfo, err := s.statObject(slot.Name)
+		if err == nil {
+			contentType := objectInfo.ContentType
+			size := objectInfo.Size
+			lastModified := objectInfo.LastModified.UTC()
+
+			info.Available = true
+			info.ContentType = &contentType
+			info.Size = &size
+			info.LastModified = &lastModified
+			info.Variants = assetVariantInfo(slot.Name, decodeAssetVariants(objectInfo.Metadata[assetVariantsMetadataKey]))
+		} else if !isObjectNotFound(err) {
+			log.Printf("Failed to stat asset %s: %v", slot.Name, err)
+		}
+
+		infos = append(infos, info)
 	}
 
-	// Upload the file
-	_, err := s.minioClient.PutObject(ctx, s.bucketName, objectName, file, header.Size, minio.PutObjectOptions{
-		ContentType: contentType,
-	})
+	return infos
+}
+
+// GetAssetURL returns the URL for an asset (for direct access)
+func (s *AssetService) GetAssetURL(objectName string) string {
+	// Assets are served through our API endpoint rather than presigned URLs
+	return fmt.Sprintf("/api/assets/%s", objectName)
+}
+
+// openObject opens an object for streaming. Missing objects are reported through the
+// returned error, which can be checked with isObjectNotFound.
+func (s *AssetService) openObject(objectName string) (*AssetObject, error) {
+	ctx := context.Background()
+
+	reader, objectInfo, err := s.store.Get(ctx, objectName)
 	if err != nil {
-		return fmt.Errorf("failed to upload hero banner: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Successfully uploaded hero banner: %s (size: %d bytes, type: %s)",
-		header.Filename, header.Size, contentType)
-	return nil
+	contentType := objectInfo.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &AssetObject{
+		ReadSeekCloser: reader,
+		ContentType:    contentType,
+		Size:           objectInfo.Size,
+		ETag:           objectInfo.ETag,
+		LastModified:   objectInfo.LastModified,
+	}, nil
 }
 
-// HasHeroBanner checks if a hero banner exists
-func (s *AssetService) HasHeroBanner() bool {
+func (s *AssetService) statObject(objectName string) (storage.ObjectInfo, error) {
 	ctx := context.Background()
-	objectName := "hero-banner"
+	return s.store.Stat(ctx, objectName)
+}
 
-	_, err := s.minioClient.StatObject(ctx, s.bucketName, objectName, minio.StatObjectOptions{})
-	return err == nil
+func (s *AssetService) putObject(objectName string, reader io.Reader, size int64, contentType string, metadata map[string]string) error {
+	ctx := context.Background()
+	return s.store.Put(ctx, objectName, reader, size, contentType, metadata)
+}
+
+func (s *AssetService) removeObject(objectName string) error {
+	ctx := context.Background()
+	return s.store.Remove(ctx, objectName)
 }
 
-// DeleteHeroBanner deletes the current hero banner
-func (s *AssetService) DeleteHeroBanner() error {
+// removeVariants deletes every stored variant of a slot
+func (s *AssetService) removeVariants(slotName string) error {
 	ctx := context.Background()
-	objectName := "hero-banner"
 
-	err := s.minioClient.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{})
+	keys, err := s.store.List(ctx, variantsPrefix(slotName))
 	if err != nil {
-		return fmt.Errorf("failed to delete hero banner: %w", err)
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.removeObject(key); err != nil {
+			return err
+		}
 	}
 
-	log.Println("Successfully deleted hero banner")
 	return nil
 }
 
-// GetAssetURL returns the URL for an asset (for direct access)
-func (s *AssetService) GetAssetURL(objectName string) (string, error) {
-	// For now, we'll serve assets through our API endpoint
-	// In production, you might want to use presigned URLs
-	return fmt.Sprintf("/api/assets/%s", objectName), nil
+func isObjectNotFound(err error) bool {
+	return errors.Is(err, storage.ErrObjectNotFound)
 }
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+// AssetSlot describes a named, single-file asset location such as the hero banner.
+// Uploading to a slot replaces whatever was stored there before. Slots with Variants
+// set also get downscaled copies of uploaded images for responsive delivery,
+// Metadata selects what image metadata survives the upload, and CachePolicy controls
+// how long browsers may reuse the file before revalidating.
+type AssetSlot struct {
+	Name         string
+	Description  string
+	AllowedTypes []string
+	MaxSize      int64
+	Variants     bool
+	Metadata     utils.ImageMetadataOptions
+	CachePolicy  CachePolicy
+}
+
+// assetSlots is the registry of slots the API accepts; unknown slot names are rejected
+var assetSlots = map[string]AssetSlot{
+	"hero-banner": {
+		Name:         "hero-banner",
+		Description:  "Background image for the home page hero section",
+		AllowedTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
+		MaxSize:      10 << 20,
+		Variants:     true,
+		Metadata:     utils.ImageMetadataOptions{KeepColorProfile: true, KeepOrientation: true},
+		CachePolicy:  CachePolicy{MaxAge: time.Hour},
+	},
+	"profile-photo": {
+		Name:         "profile-photo",
+		Description:  "Portrait shown on the about section",
+		AllowedTypes: []string{"image/jpeg", "image/png", "image/webp"},
+		MaxSize:      5 << 20,
+		Variants:     true,
+		Metadata:     utils.ImageMetadataOptions{KeepColorProfile: true, KeepOrientation: true},
+		CachePolicy:  CachePolicy{MaxAge: time.Hour},
+	},
+	"og-image": {
+		Name:         "og-image",
+		Description:  "Preview image used for social media link cards",
+		AllowedTypes: []string{"image/jpeg", "image/png"},
+		MaxSize:      5 << 20,
+		Metadata:     utils.ImageMetadataOptions{KeepColorProfile: true},
+		CachePolicy:  CachePolicy{MaxAge: 24 * time.Hour},
+	},
+	"favicon": {
+		Name:         "favicon",
+		Description:  "Browser tab icon",
+		AllowedTypes: []string{"image/png", "image/x-icon"},
+		MaxSize:      512 << 10,
+		CachePolicy:  CachePolicy{MaxAge: 24 * time.Hour},
+	},
+	"resume": {
+		Name:         "resume",
+		Description:  "Downloadable CV",
+		AllowedTypes: []string{"application/pdf"},
+		MaxSize:      10 << 20,
+		// Always revalidate so an updated CV is picked up immediately
+		CachePolicy: CachePolicy{MaxAge: 0},
+	},
+}
+
+// GetAssetSlot looks up a slot in the registry
+func GetAssetSlot(name string) (AssetSlot, bool) {
+	slot, ok := assetSlots[name]
+	return slot, ok
+}
+
+// AssetSlots returns every registered slot ordered by name
+func AssetSlots() []AssetSlot {
+	slots := make([]AssetSlot, 0, len(assetSlots))
+	for _, slot := range assetSlots {
+		slots = append(slots, slot)
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Name < slots[j].Name
+	})
+
+	return slots
+}
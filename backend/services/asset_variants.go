@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+// The variants generated for a slot are recorded in the original object's user metadata
+// as a comma-separated list of "<width>x<height>.<format>" entries, so a single stat of
+// the original is enough to choose which object to serve.
+const assetVariantsMetadataKey = "Variants"
+
+const assetVariantPrefix = "variants/"
+
+// AssetVariantRequest describes which rendition of an asset the client would like
+type AssetVariantRequest struct {
+	Width      int
+	PreferWebP bool
+}
+
+type assetVariant struct {
+	Width  int
+	Height int
+	Format string
+}
+
+func (v assetVariant) objectName(slotName string) string {
+	return fmt.Sprintf("%s%s/%d.%s", assetVariantPrefix, slotName, v.Width, v.Format)
+}
+
+func variantsPrefix(slotName string) string {
+	return assetVariantPrefix + slotName + "/"
+}
+
+func encodeAssetVariants(variants []utils.ImageVariant) string {
+	entries := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		entries = append(entries, fmt.Sprintf("%dx%d.%s", variant.Width, variant.Height, variant.Format))
+	}
+	return strings.Join(entries, ",")
+}
+
+func decodeAssetVariants(value string) []assetVariant {
+	var variants []assetVariant
+	for _, entry := range strings.Split(value, ",") {
+		var variant assetVariant
+		dimensions, format, ok := strings.Cut(strings.TrimSpace(entry), ".")
+		if !ok || utils.ImageFormatContentType(format) == "" {
+			continue
+		}
+		if _, err := fmt.Sscanf(dimensions, "%dx%d", &variant.Width, &variant.Height); err != nil {
+			continue
+		}
+		variant.Format = format
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// selectAssetVariant picks the narrowest variant at least as wide as requested, preferring
+// WebP when the client accepts it. It returns false when the original should be served.
+func selectAssetVariant(variants []assetVariant, request AssetVariantRequest) (assetVariant, bool) {
+	if request.Width <= 0 {
+		return assetVariant{}, false
+	}
+
+	var selected assetVariant
+	found := false
+	for _, variant := range variants {
+		if variant.Width < request.Width {
+			continue
+		}
+
+		if !found || variant.Width < selected.Width {
+			selected = variant
+			found = true
+			continue
+		}
+
+		if variant.Width == selected.Width && (variant.Format == utils.ImageFormatWebP) == request.PreferWebP {
+			selected = variant
+		}
+	}
+
+	return selected, found
+}
+
+func assetVariantInfo(slotName string, variants []assetVariant) []models.AssetVariant {
+	infos := make([]models.AssetVariant, 0, len(variants))
+	for _, variant := range variants {
+		infos = append(infos, models.AssetVariant{
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Format:      variant.Format,
+			ContentType: utils.ImageFormatContentType(variant.Format),
+			URL:         fmt.Sprintf("/api/assets/%s?w=%d&format=%s", slotName, variant.Width, variant.Format),
+		})
+	}
+	return infos
+}
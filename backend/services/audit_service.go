@@ -0,0 +1,91 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+// AuditService keeps the persistent audit log of changes made through the admin API
+type AuditService struct {
+	auditRepo *repository.AuditLogRepository
+}
+
+func NewAuditService(auditRepo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Record stores an entry with the state of the target before and after the change. When both
+// are objects only the fields that changed are kept; either may be nil for creations and
+// deletions.
+func (s *AuditService) Record(entry *models.AuditLogEntry, before, after interface{}) error {
+	beforeJSON, afterJSON, err := diffJSON(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit change: %w", err)
+	}
+
+	entry.Before = beforeJSON
+	entry.After = afterJSON
+
+	return s.auditRepo.CreateAuditEntry(entry)
+}
+
+func (s *AuditService) ListEntries(filter models.AuditLogFilter, limit, offset int) ([]models.AuditLogEntry, int, error) {
+	return s.auditRepo.ListAuditEntries(filter, limit, offset)
+}
+
+// Cleanup deletes entries older than the retention period. A zero retention keeps everything.
+func (s *AuditService) Cleanup(retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	return s.auditRepo.CleanupAuditLog(time.Now().Add(-retention))
+}
+
+// diffJSON encodes before and after, dropping the fields they have in common when both encode
+// to JSON objects
+func diffJSON(before, after interface{}) ([]byte, []byte, error) {
+	beforeJSON, err := encodeAuditValue(before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterJSON, err := encodeAuditValue(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var beforeFields, afterFields map[string]interface{}
+	if json.Unmarshal(beforeJSON, &beforeFields) != nil || json.Unmarshal(afterJSON, &afterFields) != nil ||
+		beforeFields == nil || afterFields == nil {
+		return beforeJSON, afterJSON, nil
+	}
+
+	for key, value := range beforeFields {
+		if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+			delete(beforeFields, key)
+			delete(afterFields, key)
+		}
+	}
+
+	if beforeJSON, err = json.Marshal(beforeFields); err != nil {
+		return nil, nil, err
+	}
+	if afterJSON, err = json.Marshal(afterFields); err != nil {
+		return nil, nil, err
+	}
+
+	return beforeJSON, afterJSON, nil
+}
+
+func encodeAuditValue(value interface{}) ([]byte, error) {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+var (
+	ErrPostNotFound    = errors.New("blog post not found")
+	ErrInvalidSchedule = errors.New("publish time must be in the future")
+)
+
+type BlogService struct {
+	blogRepo         *repository.BlogRepository
+	markdownRenderer *utils.MarkdownRenderer
+}
+
+func NewBlogService(blogRepo *repository.BlogRepository) *BlogService {
+	return &BlogService{
+		blogRepo:         blogRepo,
+		markdownRenderer: utils.NewMarkdownRenderer(),
+	}
+}
+
+func (s *BlogService) ListPublishedPosts(page, pageSize int) ([]models.BlogPost, models.Pagination, error) {
+	posts, total, err := s.blogRepo.ListPublishedPosts(pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	return posts, models.NewPagination(page, pageSize, total), nil
+}
+
+func (s *BlogService) GetPublishedPost(slug string) (*models.BlogPost, error) {
+	post, err := s.blogRepo.GetPublishedPostBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+func (s *BlogService) ListPosts(status models.BlogPostStatus, page, pageSize int) ([]models.BlogPost, models.Pagination, error) {
+	posts, total, err := s.blogRepo.ListPosts(string(status), pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	return posts, models.NewPagination(page, pageSize, total), nil
+}
+
+func (s *BlogService) GetPost(id int) (*models.BlogPost, error) {
+	post, err := s.blogRepo.GetPostByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// CreateDraft stores a new post that is not visible until it is scheduled or published
+func (s *BlogService) CreateDraft(post *models.BlogPost) (*models.BlogPost, error) {
+	if err := s.renderContent(post); err != nil {
+		return nil, err
+	}
+
+	return s.blogRepo.CreatePost(post)
+}
+
+func (s *BlogService) UpdatePost(id int, post *models.BlogPost) (*models.BlogPost, error) {
+	if err := s.renderContent(post); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.blogRepo.UpdatePost(id, post)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, ErrPostNotFound
+	}
+
+	return updated, nil
+}
+
+func (s *BlogService) SchedulePost(id int, publishAt time.Time) (*models.BlogPost, error) {
+	if !publishAt.After(time.Now()) {
+		return nil, ErrInvalidSchedule
+	}
+
+	publishAt = publishAt.UTC()
+	return s.setStatus(id, models.BlogPostStatusScheduled, &publishAt)
+}
+
+// PublishPost makes a post visible immediately, keeping the original publish date of
+// posts that are already live
+func (s *BlogService) PublishPost(id int) (*models.BlogPost, error) {
+	post, err := s.GetPost(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Status == models.BlogPostStatusPublished && post.PublishedAt != nil {
+		return post, nil
+	}
+
+	now := time.Now().UTC()
+	return s.setStatus(id, models.BlogPostStatusPublished, &now)
+}
+
+func (s *BlogService) UnpublishPost(id int) (*models.BlogPost, error) {
+	return s.setStatus(id, models.BlogPostStatusDraft, nil)
+}
+
+func (s *BlogService) DeletePost(id int) error {
+	deleted, err := s.blogRepo.DeletePost(id)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return ErrPostNotFound
+	}
+
+	return nil
+}
+
+func (s *BlogService) PreviewContent(content string) (*utils.RenderedMarkdown, error) {
+	return s.markdownRenderer.Render(content)
+}
+
+// renderContent converts the Markdown source into the sanitized HTML and table of
+// contents that are stored next to it
+func (s *BlogService) renderContent(post *models.BlogPost) error {
+	rendered, err := s.markdownRenderer.Render(post.Content)
+	if err != nil {
+		return fmt.Errorf("failed to render post content: %w", err)
+	}
+
+	post.ContentHTML = rendered.HTML
+	post.TOC = rendered.TableOfContents
+	return nil
+}
+
+func (s *BlogService) setStatus(id int, status models.BlogPostStatus, publishedAt *time.Time) (*models.BlogPost, error) {
+	post, err := s.blogRepo.UpdatePostStatus(id, status, publishedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set post status to %s: %w", status, err)
+	}
+
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
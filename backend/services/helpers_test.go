@@ -0,0 +1,30 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+// createTestAdmin adds an admin with the given role, created by a fresh owner
+func createTestAdmin(t *testing.T, db *sql.DB, role models.AdminRole) *models.Admin {
+	t.Helper()
+
+	adminRepo := repository.NewAdminRepository(db)
+	owner, err := adminRepo.CreateAdmin(dbtest.UniqueName("services-owner-"), "unused", "")
+	if err != nil {
+		t.Fatalf("failed to create owner: %v", err)
+	}
+	if role == models.AdminRoleOwner {
+		return owner
+	}
+
+	admin, err := adminRepo.CreateAdminBy(dbtest.UniqueName("services-"+string(role)+"-"), "unused", role, owner.ID)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", role, err)
+	}
+	return admin
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+var (
+	ErrInvalidIPBlockScope  = errors.New("IP block scope must be admin or all")
+	ErrInvalidIPBlockExpiry = errors.New("IP block expiry must be in the future")
+)
+
+// Reasons a client is refused, as logged with each denial
+const (
+	IPDeniedByDenyList       = "deny_list"
+	IPDeniedNotInAllowList   = "not_in_allow_list"
+	IPDeniedByTemporaryBlock = "temporary_block"
+)
+
+type cachedIPBlock struct {
+	network   *net.IPNet
+	scope     string
+	expiresAt time.Time
+}
+
+// IPAccessService decides which clients may reach the API, from the configured allow and deny
+// lists and the temporary blocks admins add at runtime. Blocks are cached in memory and
+// reloaded every BlockRefreshInterval, so checks do not query the database on every request.
+type IPAccessService struct {
+	config    *config.IPAccessConfig
+	blockRepo *repository.IPBlockRepository
+
+	mu       sync.RWMutex
+	blocks   []cachedIPBlock
+	loadedAt time.Time
+	// reloading lets one request reload the blocks while the others use the cached ones
+	reloading sync.Mutex
+}
+
+// NewIPAccessService creates the service. blockRepo may be nil, in which case only the
+// configured lists apply.
+func NewIPAccessService(cfg *config.IPAccessConfig, blockRepo *repository.IPBlockRepository) *IPAccessService {
+	return &IPAccessService{
+		config:    cfg,
+		blockRepo: blockRepo,
+	}
+}
+
+// Check reports whether ip may reach an admin or public route, and if not, why
+func (s *IPAccessService) Check(ip net.IP, admin bool) (bool, string) {
+	allow, deny := s.config.PublicAllow, s.config.PublicDeny
+	if admin {
+		allow, deny = s.config.AdminAllow, s.config.AdminDeny
+	}
+
+	if ip == nil {
+		if len(allow) > 0 {
+			return false, IPDeniedNotInAllowList
+		}
+		return true, ""
+	}
+
+	if utils.NetworksContain(deny, ip) {
+		return false, IPDeniedByDenyList
+	}
+
+	if s.isBlocked(ip, admin) {
+		return false, IPDeniedByTemporaryBlock
+	}
+
+	if len(allow) > 0 && !utils.NetworksContain(allow, ip) {
+		return false, IPDeniedNotInAllowList
+	}
+
+	return true, ""
+}
+
+// ListBlocks returns the temporary blocks that are still in force
+func (s *IPAccessService) ListBlocks() ([]models.IPBlock, error) {
+	return s.blockRepo.ListActiveIPBlocks(time.Now())
+}
+
+// Block adds a temporary block on an IP address or CIDR range. It takes effect on this instance
+// straight away and on others at their next reload.
+func (s *IPAccessService) Block(network, scope string, reason *string, expiresAt time.Time, createdBy int) (*models.IPBlock, error) {
+	parsed, err := utils.ParseNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if scope == "" {
+		scope = models.IPBlockScopeAdmin
+	}
+	if scope != models.IPBlockScopeAdmin && scope != models.IPBlockScopeAll {
+		return nil, ErrInvalidIPBlockScope
+	}
+
+	if !expiresAt.After(time.Now()) {
+		return nil, ErrInvalidIPBlockExpiry
+	}
+
+	block, err := s.blockRepo.CreateIPBlock(&models.IPBlock{
+		Network:   parsed.String(),
+		Scope:     scope,
+		Reason:    reason,
+		CreatedBy: &createdBy,
+		ExpiresAt: expiresAt.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.blocks = append(s.blocks, cachedIPBlock{network: parsed, scope: scope, expiresAt: block.ExpiresAt})
+	s.mu.Unlock()
+
+	return block, nil
+}
+
+// Unblock lifts a temporary block, reporting whether it existed
+func (s *IPAccessService) Unblock(id int) (bool, error) {
+	deleted, err := s.blockRepo.DeleteIPBlock(id)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	if err := s.LoadBlocks(); err != nil {
+		log.Printf("Failed to reload IP blocks: %v", err)
+	}
+
+	return true, nil
+}
+
+// LoadBlocks replaces the cached blocks with the ones in the database
+func (s *IPAccessService) LoadBlocks() error {
+	if s.blockRepo == nil {
+		return nil
+	}
+
+	blocks, err := s.blockRepo.ListActiveIPBlocks(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Failed reloads keep the old blocks and wait a full interval before trying again
+	s.loadedAt = time.Now()
+	if err != nil {
+		return err
+	}
+
+	cached := make([]cachedIPBlock, 0, len(blocks))
+	for _, block := range blocks {
+		network, err := utils.ParseNetwork(block.Network)
+		if err != nil {
+			log.Printf("Skipping IP block %d: %v", block.ID, err)
+			continue
+		}
+		cached = append(cached, cachedIPBlock{network: network, scope: block.Scope, expiresAt: block.ExpiresAt})
+	}
+	s.blocks = cached
+
+	return nil
+}
+
+func (s *IPAccessService) isBlocked(ip net.IP, admin bool) bool {
+	s.reloadIfStale()
+
+	now := time.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, block := range s.blocks {
+		if block.scope == models.IPBlockScopeAdmin && !admin {
+			continue
+		}
+		if now.Before(block.expiresAt) && block.network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *IPAccessService) reloadIfStale() {
+	if s.blockRepo == nil {
+		return
+	}
+
+	s.mu.RLock()
+	stale := time.Since(s.loadedAt) >= s.config.BlockRefreshInterval
+	s.mu.RUnlock()
+
+	if !stale || !s.reloading.TryLock() {
+		return
+	}
+	defer s.reloading.Unlock()
+
+	if err := s.LoadBlocks(); err != nil {
+		log.Printf("Failed to reload IP blocks: %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+var ErrInvalidLockoutScope = errors.New("lockout scope must be username or ip")
+
+const (
+	// maxBackoffDoublings bounds how many times a duration is doubled before the limit applies
+	maxBackoffDoublings = 30
+	// loginAttemptHold keeps parallel attempts out while one is in progress. It only needs to
+	// outlast a login request, since recording the outcome replaces it.
+	loginAttemptHold = 10 * time.Second
+)
+
+// LoginBlock says why and until when login attempts are being rejected
+type LoginBlock struct {
+	Scope   string
+	Subject string
+	Until   time.Time
+	// Locked is true for a lockout after too many failures, false for the back-off between attempts
+	Locked bool
+}
+
+// RetryAfterSeconds is how long the client should wait, rounded up to a whole second
+func (b *LoginBlock) RetryAfterSeconds(now time.Time) int {
+	seconds := int((b.Until.Sub(now) + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// LoginClaim is an attempt taken by ClaimAttempt. Until its outcome is recorded, other attempts
+// for the same username or IP are rejected.
+type LoginClaim struct {
+	holds []models.LoginLockout
+}
+
+type loginSubject struct {
+	scope   string
+	subject string
+}
+
+// LoginProtectionService slows down and locks out repeated failed logins, tracking each
+// username and each client IP separately
+type LoginProtectionService struct {
+	lockoutRepo *repository.LoginLockoutRepository
+	config      *config.LoginProtectionConfig
+}
+
+func NewLoginProtectionService(lockoutRepo *repository.LoginLockoutRepository, cfg *config.LoginProtectionConfig) *LoginProtectionService {
+	return &LoginProtectionService{
+		lockoutRepo: lockoutRepo,
+		config:      cfg,
+	}
+}
+
+// ClaimAttempt takes a login attempt for the username and IP, or returns the block furthest in
+// the future when either is backed off or locked. Claiming is atomic, so a burst of parallel
+// attempts gets one through and has the rest wait for its outcome. An empty username claims
+// for the IP alone.
+func (s *LoginProtectionService) ClaimAttempt(username, ipAddress string) (*LoginClaim, *LoginBlock, error) {
+	now := time.Now()
+	claim := &LoginClaim{}
+
+	for _, subject := range loginSubjects(username, ipAddress) {
+		hold, err := s.lockoutRepo.ClaimLoginAttempt(subject.scope, subject.subject, now, now.Add(loginAttemptHold))
+		if err != nil {
+			s.ReleaseAttempt(claim)
+			return nil, nil, err
+		}
+
+		if hold != nil {
+			claim.holds = append(claim.holds, *hold)
+			continue
+		}
+
+		s.ReleaseAttempt(claim)
+
+		block, err := s.currentBlock(username, ipAddress, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if block == nil {
+			// The other attempt finished in the meantime
+			block = &LoginBlock{Scope: subject.scope, Subject: subject.subject, Until: now.Add(time.Second)}
+		}
+		return nil, block, nil
+	}
+
+	return claim, nil, nil
+}
+
+// ReleaseAttempt gives up a claimed attempt without recording an outcome, so the next attempt
+// can be made straight away. Failures to release are logged, since the hold expires anyway.
+func (s *LoginProtectionService) ReleaseAttempt(claim *LoginClaim) {
+	for _, hold := range claim.holds {
+		if err := s.lockoutRepo.ReleaseLoginAttempt(hold.Scope, hold.Subject, hold.NextAttemptAt.Time); err != nil {
+			log.Printf("Failed to release login attempt for %s %s: %v", hold.Scope, hold.Subject, err)
+		}
+	}
+	claim.holds = nil
+}
+
+// currentBlock returns the block furthest in the future for the username or IP, or nil when
+// neither is held back
+func (s *LoginProtectionService) currentBlock(username, ipAddress string, now time.Time) (*LoginBlock, error) {
+	lockouts, err := s.lockoutRepo.GetLoginLockouts(username, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *LoginBlock
+	for _, lockout := range lockouts {
+		if lockout.Scope == models.LoginLockoutScopeUsername && username == "" {
+			continue
+		}
+
+		until, locked := blockedUntil(lockout, now)
+		if until.IsZero() || (block != nil && !until.After(block.Until)) {
+			continue
+		}
+
+		block = &LoginBlock{
+			Scope:   lockout.Scope,
+			Subject: lockout.Subject,
+			Until:   until,
+			Locked:  locked,
+		}
+	}
+
+	return block, nil
+}
+
+// RecordFailure counts a failed login against the username, if known, and the IP, and sets
+// the back-off or lockout that follows. It returns the records that have just been locked.
+func (s *LoginProtectionService) RecordFailure(username, ipAddress string) ([]models.LoginLockout, error) {
+	now := time.Now()
+	resetBefore := now.Add(-s.config.FailureResetAfter)
+
+	var locked []models.LoginLockout
+	for _, subject := range loginSubjects(username, ipAddress) {
+		lockout, err := s.lockoutRepo.RecordLoginFailure(subject.scope, subject.subject, now, resetBefore)
+		if err != nil {
+			return locked, err
+		}
+
+		var lockedUntil *time.Time
+		nextAttemptAt := now.Add(s.backoff(lockout.FailedAttempts))
+		if lockout.FailedAttempts >= s.config.MaxFailedAttempts {
+			until := now.Add(s.lockoutDuration(lockout.FailedAttempts))
+			lockedUntil = &until
+			nextAttemptAt = until
+		}
+
+		if err := s.lockoutRepo.SetLoginLockoutTimes(subject.scope, subject.subject, nextAttemptAt, lockedUntil); err != nil {
+			return locked, err
+		}
+
+		if lockedUntil != nil {
+			lockout.NextAttemptAt = models.NullTime{Time: nextAttemptAt, Valid: true}
+			lockout.LockedUntil = models.NullTime{Time: *lockedUntil, Valid: true}
+			locked = append(locked, *lockout)
+		}
+	}
+
+	return locked, nil
+}
+
+// RecordSuccess clears the failures of the admin who logged in and of the IP they used
+func (s *LoginProtectionService) RecordSuccess(username, ipAddress string) error {
+	if _, err := s.lockoutRepo.DeleteLoginLockout(models.LoginLockoutScopeIP, ipAddress); err != nil {
+		return err
+	}
+
+	_, err := s.lockoutRepo.DeleteLoginLockout(models.LoginLockoutScopeUsername, username)
+	return err
+}
+
+// ListActive returns every username and IP currently backed off or locked out
+func (s *LoginProtectionService) ListActive() ([]models.LoginLockout, error) {
+	return s.lockoutRepo.ListActiveLoginLockouts(time.Now())
+}
+
+// Unlock lifts a lockout and forgets the failures behind it, reporting whether there were any
+func (s *LoginProtectionService) Unlock(scope, subject string) (bool, error) {
+	if scope != models.LoginLockoutScopeUsername && scope != models.LoginLockoutScopeIP {
+		return false, ErrInvalidLockoutScope
+	}
+
+	return s.lockoutRepo.DeleteLoginLockout(scope, subject)
+}
+
+// Cleanup deletes records whose failures have been forgotten and whose lock has ended
+func (s *LoginProtectionService) Cleanup() error {
+	now := time.Now()
+	return s.lockoutRepo.CleanupLoginLockouts(now.Add(-s.config.FailureResetAfter), now)
+}
+
+// backoff is the wait after the nth consecutive failure: BackoffBase doubled for each
+// failure after the first, up to BackoffMax
+func (s *LoginProtectionService) backoff(failedAttempts int) time.Duration {
+	return doubled(s.config.BackoffBase, failedAttempts-1, s.config.BackoffMax)
+}
+
+// lockoutDuration is LockoutDuration for the failure that reaches MaxFailedAttempts, doubled
+// for each failure after that, up to MaxLockoutDuration
+func (s *LoginProtectionService) lockoutDuration(failedAttempts int) time.Duration {
+	return doubled(s.config.LockoutDuration, failedAttempts-s.config.MaxFailedAttempts, s.config.MaxLockoutDuration)
+}
+
+// loginSubjects lists the records a login counts against: the IP, and the username if known
+func loginSubjects(username, ipAddress string) []loginSubject {
+	subjects := []loginSubject{{models.LoginLockoutScopeIP, ipAddress}}
+	if username != "" {
+		subjects = append(subjects, loginSubject{models.LoginLockoutScopeUsername, username})
+	}
+	return subjects
+}
+
+func doubled(base time.Duration, times int, limit time.Duration) time.Duration {
+	if times < 0 {
+		times = 0
+	}
+	// Compared before shifting, since an overflowing shift can wrap to a small positive value
+	if times > maxBackoffDoublings || base <= 0 || base > limit>>uint(times) {
+		return limit
+	}
+	return base << uint(times)
+}
+
+func blockedUntil(lockout models.LoginLockout, now time.Time) (time.Time, bool) {
+	if lockout.LockedUntil.Valid && lockout.LockedUntil.Time.After(now) {
+		return lockout.LockedUntil.Time, true
+	}
+	if lockout.NextAttemptAt.Valid && lockout.NextAttemptAt.Time.After(now) {
+		return lockout.NextAttemptAt.Time, false
+	}
+	return time.Time{}, false
+}
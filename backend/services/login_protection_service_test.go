@@ -0,0 +1,181 @@
+package services
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+func testLoginProtectionConfig() *config.LoginProtectionConfig {
+	return &config.LoginProtectionConfig{
+		MaxFailedAttempts:  5,
+		LockoutDuration:    15 * time.Minute,
+		MaxLockoutDuration: 24 * time.Hour,
+		BackoffBase:        time.Second,
+		BackoffMax:         30 * time.Second,
+		FailureResetAfter:  24 * time.Hour,
+	}
+}
+
+func TestDoubled(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  time.Duration
+		times int
+		limit time.Duration
+		want  time.Duration
+	}{
+		{"no doubling", time.Second, 0, time.Minute, time.Second},
+		{"negative count", time.Second, -3, time.Minute, time.Second},
+		{"below limit", time.Second, 3, time.Minute, 8 * time.Second},
+		{"exactly at limit", time.Second, 6, 64 * time.Second, 64 * time.Second},
+		{"just over limit", time.Second, 6, 63 * time.Second, 63 * time.Second},
+		{"at the doubling cap", time.Nanosecond, maxBackoffDoublings, time.Hour, time.Duration(1) << maxBackoffDoublings},
+		{"past the doubling cap", time.Nanosecond, maxBackoffDoublings + 1, 100 * time.Hour, 100 * time.Hour},
+		{"huge count", time.Second, math.MaxInt, time.Hour, time.Hour},
+		{"shift overflows to a negative value", 15 * time.Minute, 25, 24 * time.Hour, 24 * time.Hour},
+		{"shift overflows to a small positive value", 1<<34 + 1, maxBackoffDoublings, time.Hour, time.Hour},
+		{"shift overflows at the largest limit", math.MaxInt64/2 + 1, 1, math.MaxInt64, math.MaxInt64},
+		{"base above limit", time.Hour, 0, time.Minute, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doubled(tt.base, tt.times, tt.limit); got != tt.want {
+				t.Errorf("doubled(%v, %d, %v) = %v, want %v", tt.base, tt.times, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := &LoginProtectionService{config: testLoginProtectionConfig()}
+
+	tests := []struct {
+		failedAttempts int
+		want           time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{6, 30 * time.Second},
+		{1000, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := s.backoff(tt.failedAttempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.failedAttempts, got, tt.want)
+		}
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	s := &LoginProtectionService{config: testLoginProtectionConfig()}
+
+	tests := []struct {
+		failedAttempts int
+		want           time.Duration
+	}{
+		{5, 15 * time.Minute},
+		{6, 30 * time.Minute},
+		{11, 16 * time.Hour},
+		{12, 24 * time.Hour},
+		{math.MaxInt32, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := s.lockoutDuration(tt.failedAttempts); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", tt.failedAttempts, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		remaining time.Duration
+		want      int
+	}{
+		{-time.Minute, 1},
+		{0, 1},
+		{time.Nanosecond, 1},
+		{time.Second, 1},
+		{time.Second + time.Nanosecond, 2},
+		{29*time.Second + 500*time.Millisecond, 30},
+		{15 * time.Minute, 900},
+	}
+
+	for _, tt := range tests {
+		block := &LoginBlock{Until: now.Add(tt.remaining)}
+		if got := block.RetryAfterSeconds(now); got != tt.want {
+			t.Errorf("RetryAfterSeconds with %v remaining = %d, want %d", tt.remaining, got, tt.want)
+		}
+	}
+}
+
+func TestClaimAttemptAdmitsOneOfParallelAttempts(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repository.NewLoginLockoutRepository(db)
+	s := NewLoginProtectionService(repo, testLoginProtectionConfig())
+
+	username := dbtest.UniqueName("claim-test-")
+	ipAddress := randomTestIP(t)
+	t.Cleanup(func() {
+		repo.DeleteLoginLockout(models.LoginLockoutScopeUsername, username)
+		repo.DeleteLoginLockout(models.LoginLockoutScopeIP, ipAddress)
+	})
+
+	const attempts = 20
+	claims := make([]*LoginClaim, attempts)
+	blocks := make([]*LoginBlock, attempts)
+	errs := make([]error, attempts)
+
+	var start, done sync.WaitGroup
+	start.Add(1)
+	for i := range attempts {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			start.Wait()
+			claims[i], blocks[i], errs[i] = s.ClaimAttempt(username, ipAddress)
+		}()
+	}
+	start.Done()
+	done.Wait()
+
+	claimed := 0
+	for i := range attempts {
+		switch {
+		case errs[i] != nil:
+			t.Errorf("attempt %d: %v", i, errs[i])
+		case claims[i] != nil:
+			claimed++
+		case blocks[i] == nil:
+			t.Errorf("attempt %d got neither a claim nor a block", i)
+		}
+	}
+
+	if claimed != 1 {
+		t.Fatalf("%d of %d parallel attempts were claimed, want exactly 1", claimed, attempts)
+	}
+}
+
+// randomTestIP returns an address in 198.18.0.0/15, the benchmarking range, so runs do not share
+// lockout records
+func randomTestIP(t *testing.T) string {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Sprintf("198.%d.%d.%d", 18+b[0]%2, b[1], b[2])
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+	_ "golang.org/x/image/webp"
+)
+
+var ErrMediaNotFound = errors.New("media not found")
+
+// MediaAllowedTypes lists the MIME types accepted by the media library
+var MediaAllowedTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Media keys are the SHA-256 of the file contents plus an extension, so identical
+// uploads share one object and a key's content never changes
+var mediaKeyPattern = regexp.MustCompile(`^[a-f0-9]{64}\.(jpg|png|gif|webp)$`)
+
+const mediaObjectPrefix = "media/"
+
+// Library images are mostly photos, so color and rotation are preserved while EXIF,
+// XMP and IPTC blocks are removed
+var mediaMetadataOptions = utils.ImageMetadataOptions{KeepColorProfile: true, KeepOrientation: true}
+
+// Keys are derived from the file contents, so a response can never go stale
+var mediaCachePolicy = CachePolicy{MaxAge: 365 * 24 * time.Hour, Immutable: true}
+
+type MediaService struct {
+	assetService *AssetService
+	mediaRepo    *repository.MediaRepository
+}
+
+func NewMediaService(assetService *AssetService, mediaRepo *repository.MediaRepository) *MediaService {
+	return &MediaService{
+		assetService: assetService,
+		mediaRepo:    mediaRepo,
+	}
+}
+
+func (s *MediaService) ListMedia(search, mimeType string, page, pageSize int) ([]models.Media, int, error) {
+	media, total, err := s.mediaRepo.ListMedia(search, mimeType, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range media {
+		s.populateURL(&media[i])
+	}
+
+	return media, total, nil
+}
+
+func (s *MediaService) GetMedia(id int) (*models.Media, error) {
+	media, err := s.mediaRepo.GetMediaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, ErrMediaNotFound
+	}
+
+	s.populateURL(media)
+	return media, nil
+}
+
+// UploadMedia stores a validated image in the library. If the same content was uploaded
+// before, the existing record is returned and created is false.
+func (s *MediaService) UploadMedia(file multipart.File, header *multipart.FileHeader, altText *string, uploadedBy *int) (media *models.Media, created bool, err error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	// Strip before hashing so the key identifies exactly what is served
+	data, err = utils.StripImageMetadata(data, mediaMetadataOptions)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	hash := sha256.Sum256(data)
+	contentHash := hex.EncodeToString(hash[:])
+
+	existing, err := s.mediaRepo.GetMediaByHash(contentHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		s.populateURL(existing)
+		return existing, false, nil
+	}
+
+	mimeType := http.DetectContentType(data)
+	extension, ok := mediaExtensions[mimeType]
+	if !ok {
+		return nil, false, fmt.Errorf("unsupported media type: %s", mimeType)
+	}
+
+	media = &models.Media{
+		ObjectKey:   contentHash + extension,
+		ContentHash: contentHash,
+		Filename:    header.Filename,
+		MimeType:    mimeType,
+		SizeBytes:   int64(len(data)),
+		AltText:     altText,
+		UploadedBy:  uploadedBy,
+	}
+
+	if imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		width, height := imageConfig.Width, imageConfig.Height
+		// Report the displayed size of photos stored rotated
+		if utils.ImageOrientation(data) >= 5 {
+			width, height = height, width
+		}
+		media.Width = &width
+		media.Height = &height
+	}
+
+	objectName := mediaObjectPrefix + media.ObjectKey
+	if err := s.assetService.putObject(objectName, bytes.NewReader(data), media.SizeBytes, mimeType, nil); err != nil {
+		return nil, false, fmt.Errorf("failed to store media: %w", err)
+	}
+
+	saved, err := s.mediaRepo.CreateMedia(media)
+	if err != nil {
+		// A concurrent upload of the same file won the insert; the object is shared so keep it
+		if errors.Is(err, repository.ErrDuplicateMedia) {
+			existing, lookupErr := s.mediaRepo.GetMediaByHash(contentHash)
+			if lookupErr != nil || existing == nil {
+				return nil, false, err
+			}
+			s.populateURL(existing)
+			return existing, false, nil
+		}
+
+		if removeErr := s.assetService.removeObject(objectName); removeErr != nil {
+			log.Printf("Failed to remove orphaned media object %s: %v", objectName, removeErr)
+		}
+		return nil, false, err
+	}
+
+	log.Printf("Successfully uploaded media %s: %s (size: %d bytes, type: %s)",
+		saved.ObjectKey, saved.Filename, saved.SizeBytes, saved.MimeType)
+
+	s.populateURL(saved)
+	return saved, true, nil
+}
+
+func (s *MediaService) DeleteMedia(id int) error {
+	media, err := s.mediaRepo.GetMediaByID(id)
+	if err != nil {
+		return err
+	}
+	if media == nil {
+		return ErrMediaNotFound
+	}
+
+	deleted, err := s.mediaRepo.DeleteMedia(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrMediaNotFound
+	}
+
+	// The record is gone either way; a leftover object is only wasted space
+	if err := s.assetService.removeObject(mediaObjectPrefix + media.ObjectKey); err != nil {
+		log.Printf("Failed to remove media object %s: %v", media.ObjectKey, err)
+	}
+
+	return nil
+}
+
+// OpenMedia opens the stored file for a public media key
+func (s *MediaService) OpenMedia(key string) (*AssetObject, error) {
+	if !mediaKeyPattern.MatchString(key) {
+		return nil, ErrMediaNotFound
+	}
+
+	object, err := s.assetService.openObject(mediaObjectPrefix + key)
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("failed to get media %s: %w", key, err)
+	}
+
+	object.CachePolicy = mediaCachePolicy
+	return object, nil
+}
+
+func (s *MediaService) populateURL(media *models.Media) {
+	media.URL = "/api/media/" + media.ObjectKey
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFANotPending     = errors.New("no two-factor enrolment is in progress")
+	ErrInvalidMFACode    = errors.New("two-factor code is invalid")
+)
+
+const (
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet leaves out characters that are easily confused when copied by hand
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// TOTPEnrolment is the shared secret an admin adds to their authenticator app
+type TOTPEnrolment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type MFAService struct {
+	adminRepo        *repository.AdminRepository
+	recoveryCodeRepo *repository.RecoveryCodeRepository
+	issuer           string
+}
+
+func NewMFAService(adminRepo *repository.AdminRepository, recoveryCodeRepo *repository.RecoveryCodeRepository, issuer string) *MFAService {
+	return &MFAService{
+		adminRepo:        adminRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		issuer:           issuer,
+	}
+}
+
+// BeginTOTPEnrolment generates a new secret for the admin. It is not enforced at login until
+// ConfirmTOTPEnrolment proves the authenticator app was set up correctly.
+func (s *MFAService) BeginTOTPEnrolment(admin *models.Admin) (*TOTPEnrolment, error) {
+	if admin.TOTPEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.adminRepo.SetTOTPSecret(admin.ID, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stored {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return &TOTPEnrolment{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(secret, s.issuer, admin.Username),
+	}, nil
+}
+
+// ConfirmTOTPEnrolment activates TOTP once the first code checks out and returns a fresh set
+// of recovery codes. Only their hashes are stored, so they cannot be shown again.
+func (s *MFAService) ConfirmTOTPEnrolment(admin *models.Admin, code string) ([]string, error) {
+	if admin.TOTPEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if admin.TOTPSecret == nil {
+		return nil, ErrMFANotPending
+	}
+
+	step, ok := auth.ValidateTOTPCode(*admin.TOTPSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored before TOTP is enabled, so an account is never left enforcing TOTP without recovery codes
+	if err := s.recoveryCodeRepo.ReplaceRecoveryCodes(admin.ID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.adminRepo.EnableTOTP(admin.ID, step)
+	if err != nil {
+		return nil, err
+	}
+
+	if !enabled {
+		return nil, ErrMFANotPending
+	}
+
+	return codes, nil
+}
+
+// DisableTOTP turns TOTP off after checking a current code or recovery code
+func (s *MFAService) DisableTOTP(admin *models.Admin, code string) error {
+	if _, err := s.VerifyCode(admin, code); err != nil {
+		return err
+	}
+
+	if err := s.adminRepo.DisableTOTP(admin.ID); err != nil {
+		return err
+	}
+
+	return s.recoveryCodeRepo.DeleteRecoveryCodes(admin.ID)
+}
+
+// VerifyCode checks a second factor for an admin with TOTP enabled. Six digit codes are
+// checked as TOTP and each time step is accepted only once; anything else is treated as a
+// recovery code and consumed. It reports whether a recovery code was used.
+func (s *MFAService) VerifyCode(admin *models.Admin, code string) (bool, error) {
+	if !admin.TOTPEnabledAt.Valid || admin.TOTPSecret == nil {
+		return false, ErrMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		step, ok := auth.ValidateTOTPCode(*admin.TOTPSecret, code, time.Now())
+		if !ok {
+			return false, ErrInvalidMFACode
+		}
+
+		recorded, err := s.adminRepo.RecordTOTPStep(admin.ID, step)
+		if err != nil {
+			return false, err
+		}
+
+		if !recorded {
+			return false, ErrInvalidMFACode
+		}
+
+		return false, nil
+	}
+
+	consumed, err := s.recoveryCodeRepo.ConsumeRecoveryCode(admin.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, err
+	}
+
+	if !consumed {
+		return false, ErrInvalidMFACode
+	}
+
+	return true, nil
+}
+
+func (s *MFAService) Status(admin *models.Admin) (*models.MFAStatusResponse, error) {
+	status := &models.MFAStatusResponse{
+		TOTPEnabled:   admin.TOTPEnabledAt.Valid,
+		TOTPEnabledAt: admin.TOTPEnabledAt,
+	}
+
+	if status.TOTPEnabled {
+		remaining, err := s.recoveryCodeRepo.CountRecoveryCodes(admin.ID)
+		if err != nil {
+			return nil, err
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+
+	return status, nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// generateRecoveryCodes returns codes formatted as xxxxx-xxxxx along with the hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	codeHashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 10)
+		for j := range raw {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+			raw[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+
+		code := string(raw[:5]) + "-" + string(raw[5:])
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	return codes, codeHashes, nil
+}
+
+// normalizeRecoveryCode makes codes match regardless of case, spacing or dashes
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+var (
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	ErrPasswordUnchanged = errors.New("new password must differ from the current password")
+	ErrInvalidResetToken = errors.New("password reset token is invalid or has expired")
+)
+
+const passwordResetTokenTTL = 1 * time.Hour
+
+type PasswordService struct {
+	authService    *auth.AuthService
+	adminRepo      *repository.AdminRepository
+	resetRepo      *repository.PasswordResetRepository
+	sessionService *SessionService
+}
+
+func NewPasswordService(
+	authService *auth.AuthService,
+	adminRepo *repository.AdminRepository,
+	resetRepo *repository.PasswordResetRepository,
+	sessionService *SessionService,
+) *PasswordService {
+	return &PasswordService{
+		authService:    authService,
+		adminRepo:      adminRepo,
+		resetRepo:      resetRepo,
+		sessionService: sessionService,
+	}
+}
+
+// ChangePassword replaces an admin's password after confirming the current one and signs
+// out every session except currentSessionID. Legacy-hash accounts cannot be verified and
+// must use a reset token instead.
+func (s *PasswordService) ChangePassword(admin *models.Admin, currentSessionID, currentPassword, newPassword string) error {
+	if err := s.authService.VerifyPasswordWithHashVersion(admin.PasswordHash, currentPassword, admin.HashVersion, admin.PasswordSalt); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	if newPassword == currentPassword {
+		return ErrPasswordUnchanged
+	}
+
+	if err := s.setPassword(admin.ID, newPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.sessionService.RevokeOtherSessions(admin.ID, currentSessionID, RevocationReasonPasswordChanged); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IssueResetToken creates a single-use reset token for an admin, replacing any outstanding ones.
+// Only a hash is stored, so the returned token cannot be recovered later.
+func (s *PasswordService) IssueResetToken(adminID int, createdBy *int) (string, time.Time, error) {
+	token, err := generateToken(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if err := s.resetRepo.InvalidateResetTokens(adminID); err != nil {
+		return "", time.Time{}, err
+	}
+
+	created, err := s.resetRepo.CreateResetToken(adminID, hashToken(token), createdBy, time.Now().Add(passwordResetTokenTTL))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, created.ExpiresAt, nil
+}
+
+// ResetPassword redeems a reset token, sets a new password and signs out every session,
+// returning the affected admin
+func (s *PasswordService) ResetPassword(token, newPassword string) (*models.Admin, error) {
+	resetToken, err := s.resetRepo.ConsumeResetToken(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if resetToken == nil {
+		return nil, ErrInvalidResetToken
+	}
+
+	admin, err := s.adminRepo.GetAdminByID(resetToken.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if admin == nil {
+		return nil, ErrInvalidResetToken
+	}
+
+	if err := s.setPassword(admin.ID, newPassword); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessionService.RevokeAllSessions(admin.ID, RevocationReasonPasswordReset); err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+func (s *PasswordService) setPassword(adminID int, newPassword string) error {
+	passwordHash, err := s.authService.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.adminRepo.UpdateAdminPassword(adminID, passwordHash); err != nil {
+		return err
+	}
+
+	if err := s.resetRepo.InvalidateResetTokens(adminID); err != nil {
+		log.Printf("Warning: Failed to invalidate password reset tokens for admin %d: %v", adminID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/Wildcard209/portfolio-webapplication/utils"
+)
+
+var (
+	ErrSessionRevoked = errors.New("session is invalid or has been revoked")
+	// ErrRefreshTokenReused also matches ErrSessionRevoked, since the session is revoked as a result
+	ErrRefreshTokenReused = fmt.Errorf("%w: refresh token was reused", ErrSessionRevoked)
+)
+
+const (
+	// sessionTouchInterval limits how often authenticated requests update last_used_at
+	sessionTouchInterval = 5 * time.Minute
+	// refreshReuseGracePeriod lets requests that were already in flight with the previous
+	// refresh token through, instead of treating them as a replay
+	refreshReuseGracePeriod = 10 * time.Second
+)
+
+// Reasons recorded against access tokens revoked along with their session
+const (
+	RevocationReasonLogout          = "logout"
+	RevocationReasonSessionRevoked  = "session_revoked"
+	RevocationReasonPasswordChanged = "password_changed"
+	RevocationReasonPasswordReset   = "password_reset"
+	RevocationReasonAdminDisabled   = "admin_disabled"
+	RevocationReasonRefreshReuse    = "refresh_token_reused"
+)
+
+// AuthenticatedSession is the result of logging in or refreshing a session. Tokens is nil
+// when a concurrent request already rotated the session and no new pair was issued.
+type AuthenticatedSession struct {
+	Admin     *models.Admin
+	SessionID string
+	Tokens    *auth.TokenPair
+}
+
+type SessionService struct {
+	authService    *auth.AuthService
+	adminRepo      *repository.AdminRepository
+	sessionRepo    *repository.SessionRepository
+	securityLogger *utils.SecurityLogger
+}
+
+func NewSessionService(authService *auth.AuthService, adminRepo *repository.AdminRepository, sessionRepo *repository.SessionRepository) *SessionService {
+	return &SessionService{
+		authService:    authService,
+		adminRepo:      adminRepo,
+		sessionRepo:    sessionRepo,
+		securityLogger: utils.NewSecurityLogger(),
+	}
+}
+
+// StartSession opens a new session for an admin whose credentials have been verified
+func (s *SessionService) StartSession(admin *models.Admin, ipAddress, userAgent string) (*AuthenticatedSession, error) {
+	sessionID, err := generateToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenPair, err := s.authService.GenerateTokenPair(admin.ID, admin.Username, admin.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessionRepo.CreateSession(sessionID, admin.ID, hashToken(tokenPair.RefreshToken), userAgent, ipAddress, tokenPair.RefreshExpiresAt, tokenPair.AccessTokenID, tokenPair.AccessExpiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.adminRepo.RecordLogin(admin.ID); err != nil {
+		log.Printf("Warning: Failed to record login for admin %d: %v", admin.ID, err)
+	}
+
+	return &AuthenticatedSession{
+		Admin:     admin,
+		SessionID: sessionID,
+		Tokens:    tokenPair,
+	}, nil
+}
+
+// RefreshSession exchanges a refresh token for a new token pair in the same session.
+// Each session is a rotation family: presenting a token the family has already rotated
+// past revokes the whole session and yields ErrRefreshTokenReused.
+func (s *SessionService) RefreshSession(refreshToken, ipAddress, userAgent string) (*AuthenticatedSession, error) {
+	claims, err := s.authService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, ErrSessionRevoked
+	}
+
+	session, err := s.sessionRepo.GetActiveSession(claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil || session.AdminID != claims.UserID {
+		return nil, ErrSessionRevoked
+	}
+
+	tokenHash := hashToken(refreshToken)
+	if session.RefreshTokenHash != tokenHash {
+		return s.handleStaleRefreshToken(session, tokenHash, ipAddress, userAgent)
+	}
+
+	admin, err := s.activeAdmin(session.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenPair, err := s.authService.GenerateTokenPair(admin.ID, admin.Username, admin.Role, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.sessionRepo.RotateSession(
+		session.ID, tokenHash, hashToken(tokenPair.RefreshToken), tokenPair.RefreshExpiresAt,
+		userAgent, ipAddress, tokenPair.AccessTokenID, tokenPair.AccessExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another request rotated or revoked the session between the lookup and the update
+	if rotated == nil {
+		current, err := s.sessionRepo.GetActiveSession(session.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			return nil, ErrSessionRevoked
+		}
+
+		return s.handleStaleRefreshToken(current, tokenHash, ipAddress, userAgent)
+	}
+
+	return &AuthenticatedSession{
+		Admin:     admin,
+		SessionID: session.ID,
+		Tokens:    tokenPair,
+	}, nil
+}
+
+// handleStaleRefreshToken deals with a genuine refresh token that is no longer the session's
+// current one. The token replaced moments ago is accepted without issuing a new pair, since
+// parallel requests routinely race to refresh. Anything older means the token was copied,
+// so the session is revoked and both the attacker and the legitimate user must log in again.
+func (s *SessionService) handleStaleRefreshToken(session *models.Session, tokenHash, ipAddress, userAgent string) (*AuthenticatedSession, error) {
+	if session.PreviousTokenHash != nil && *session.PreviousTokenHash == tokenHash &&
+		session.RotatedAt.Valid && time.Since(session.RotatedAt.Time) < refreshReuseGracePeriod {
+		admin, err := s.activeAdmin(session.AdminID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AuthenticatedSession{
+			Admin:     admin,
+			SessionID: session.ID,
+		}, nil
+	}
+
+	revoked, err := s.RevokeSession(session.AdminID, session.ID, RevocationReasonRefreshReuse)
+	if err != nil {
+		return nil, err
+	}
+
+	s.securityLogger.LogSecurityEvent("refresh_token_reuse_detected", map[string]interface{}{
+		"admin_id":       session.AdminID,
+		"session_id":     session.ID,
+		"client_ip":      ipAddress,
+		"user_agent":     userAgent,
+		"family_revoked": revoked,
+		"severity":       "HIGH",
+	})
+
+	return nil, ErrRefreshTokenReused
+}
+
+// ValidateSession resolves access token claims to their admin, rejecting revoked sessions
+// and disabled accounts
+func (s *SessionService) ValidateSession(claims *auth.CustomClaims, ipAddress string) (*models.Admin, error) {
+	session, err := s.sessionRepo.GetActiveSession(claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil || session.AdminID != claims.UserID {
+		return nil, ErrSessionRevoked
+	}
+
+	admin, err := s.activeAdmin(session.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(session.LastUsedAt) > sessionTouchInterval {
+		go func() {
+			if err := s.sessionRepo.TouchSession(session.ID, ipAddress); err != nil {
+				log.Printf("Failed to update session activity: %v", err)
+			}
+		}()
+	}
+
+	return admin, nil
+}
+
+// ListSessions returns an admin's active sessions, flagging the one making the request
+func (s *SessionService) ListSessions(adminID int, currentSessionID string) ([]models.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveSessions(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends one of an admin's sessions and its access token, reporting whether it was active
+func (s *SessionService) RevokeSession(adminID int, sessionID, reason string) (bool, error) {
+	session, err := s.sessionRepo.RevokeSession(sessionID, adminID)
+	if err != nil || session == nil {
+		return false, err
+	}
+
+	s.revokeAccessTokens([]models.Session{*session}, reason)
+	return true, nil
+}
+
+// RevokeOtherSessions ends every session of an admin except the current one
+func (s *SessionService) RevokeOtherSessions(adminID int, currentSessionID, reason string) (int64, error) {
+	sessions, err := s.sessionRepo.RevokeOtherSessions(adminID, currentSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.revokeAccessTokens(sessions, reason)
+	return int64(len(sessions)), nil
+}
+
+func (s *SessionService) RevokeAllSessions(adminID int, reason string) (int64, error) {
+	sessions, err := s.sessionRepo.RevokeAllSessions(adminID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.revokeAccessTokens(sessions, reason)
+	return int64(len(sessions)), nil
+}
+
+// revokeAccessTokens adds the latest access token of each revoked session to the revocation
+// list, so it stops working immediately rather than when it expires. The sessions are already
+// revoked at this point, so a failure to persist is logged rather than returned.
+func (s *SessionService) revokeAccessTokens(sessions []models.Session, reason string) {
+	for _, session := range sessions {
+		if session.AccessTokenID == nil || !session.AccessExpiresAt.Valid {
+			continue
+		}
+
+		adminID := session.AdminID
+		err := s.authService.RevokeAccessToken(models.RevokedToken{
+			TokenID:   *session.AccessTokenID,
+			AdminID:   &adminID,
+			Reason:    reason,
+			ExpiresAt: session.AccessExpiresAt.Time,
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to persist access token revocation for session %s: %v", session.ID, err)
+		}
+	}
+}
+
+func (s *SessionService) activeAdmin(adminID int) (*models.Admin, error) {
+	admin, err := s.adminRepo.GetAdminByID(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if admin == nil || !admin.IsActive {
+		return nil, ErrSessionRevoked
+	}
+
+	return admin, nil
+}
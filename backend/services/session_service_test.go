@@ -0,0 +1,162 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+)
+
+func newTestSessionService(t *testing.T) (*SessionService, *auth.AuthService, *sql.DB) {
+	t.Helper()
+
+	db := dbtest.Open(t)
+	keys, err := auth.LoadKeySet("session-test-secret", "", nil)
+	if err != nil {
+		t.Fatalf("failed to load signing keys: %v", err)
+	}
+
+	authService := auth.NewAuthService(keys, auth.NewRevocationList(repository.NewRevokedTokenRepository(db)), time.Hour)
+	s := NewSessionService(authService, repository.NewAdminRepository(db), repository.NewSessionRepository(db))
+	return s, authService, db
+}
+
+// startTestSession signs in a new editor and returns the session with its first token pair
+func startTestSession(t *testing.T, s *SessionService, db *sql.DB) *AuthenticatedSession {
+	t.Helper()
+
+	session, err := s.StartSession(createTestAdmin(t, db, models.AdminRoleEditor), "198.51.100.7", "session-test")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	return session
+}
+
+// refresh rotates the session and fails the test unless a new token pair is issued
+func refresh(t *testing.T, s *SessionService, refreshToken string) *auth.TokenPair {
+	t.Helper()
+
+	refreshed, err := s.RefreshSession(refreshToken, "198.51.100.7", "session-test")
+	if err != nil {
+		t.Fatalf("failed to refresh session: %v", err)
+	}
+	if refreshed.Tokens == nil {
+		t.Fatal("refresh did not issue a new token pair")
+	}
+	return refreshed.Tokens
+}
+
+func TestRefreshSessionRotates(t *testing.T) {
+	s, _, db := newTestSessionService(t)
+	started := startTestSession(t, s, db)
+
+	second := refresh(t, s, started.Tokens.RefreshToken)
+	if second.RefreshToken == started.Tokens.RefreshToken || second.AccessTokenID == started.Tokens.AccessTokenID {
+		t.Error("refresh reused the previous tokens")
+	}
+
+	refreshed, err := s.RefreshSession(second.RefreshToken, "198.51.100.7", "session-test")
+	if err != nil {
+		t.Fatalf("the rotated refresh token was refused: %v", err)
+	}
+	if refreshed.SessionID != started.SessionID {
+		t.Errorf("session ID changed from %s to %s", started.SessionID, refreshed.SessionID)
+	}
+}
+
+func TestRefreshSessionAcceptsReuseWithinGracePeriod(t *testing.T) {
+	s, _, db := newTestSessionService(t)
+	started := startTestSession(t, s, db)
+	second := refresh(t, s, started.Tokens.RefreshToken)
+
+	// A request that was in flight with the previous token when the session rotated
+	raced, err := s.RefreshSession(started.Tokens.RefreshToken, "198.51.100.7", "session-test")
+	if err != nil {
+		t.Fatalf("reuse within the grace period was refused: %v", err)
+	}
+	if raced.Tokens != nil {
+		t.Error("reuse within the grace period rotated the session again")
+	}
+	if raced.SessionID != started.SessionID {
+		t.Errorf("session ID = %s, want %s", raced.SessionID, started.SessionID)
+	}
+
+	// The session is still usable with the current token
+	refresh(t, s, second.RefreshToken)
+}
+
+func TestRefreshSessionReuseRevokesFamily(t *testing.T) {
+	tests := []struct {
+		name string
+		// reuse picks the stale refresh token to present, given the three pairs issued so far
+		reuse    func(pairs []*auth.TokenPair) string
+		backdate bool
+	}{
+		{
+			name:     "previous token after the grace period",
+			reuse:    func(pairs []*auth.TokenPair) string { return pairs[1].RefreshToken },
+			backdate: true,
+		},
+		{
+			name:  "older token within the grace period",
+			reuse: func(pairs []*auth.TokenPair) string { return pairs[0].RefreshToken },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, authService, db := newTestSessionService(t)
+			started := startTestSession(t, s, db)
+
+			pairs := []*auth.TokenPair{started.Tokens}
+			pairs = append(pairs, refresh(t, s, pairs[0].RefreshToken))
+			pairs = append(pairs, refresh(t, s, pairs[1].RefreshToken))
+
+			if tt.backdate {
+				if _, err := db.Exec("UPDATE sessions SET rotated_at = $1 WHERE id = $2", time.Now().Add(-refreshReuseGracePeriod-time.Second), started.SessionID); err != nil {
+					t.Fatalf("failed to backdate rotation: %v", err)
+				}
+			}
+
+			_, err := s.RefreshSession(tt.reuse(pairs), "203.0.113.9", "attacker")
+			if !errors.Is(err, ErrRefreshTokenReused) || !errors.Is(err, ErrSessionRevoked) {
+				t.Fatalf("got error %v, want ErrRefreshTokenReused", err)
+			}
+
+			// The current refresh token belongs to the same family and dies with it
+			if _, err := s.RefreshSession(pairs[2].RefreshToken, "198.51.100.7", "session-test"); !errors.Is(err, ErrSessionRevoked) {
+				t.Errorf("current refresh token: got error %v, want ErrSessionRevoked", err)
+			}
+
+			// The latest access token is on the revocation list, so it is refused without a
+			// session lookup
+			if _, err := authService.ValidateAccessToken(pairs[2].AccessToken); err == nil {
+				t.Error("latest access token was not revoked")
+			}
+
+			var reason string
+			if err := db.QueryRow("SELECT reason FROM revoked_tokens WHERE jti = $1", pairs[2].AccessTokenID).Scan(&reason); err != nil {
+				t.Fatalf("access token revocation was not persisted: %v", err)
+			}
+			if reason != RevocationReasonRefreshReuse {
+				t.Errorf("revocation reason = %q, want %q", reason, RevocationReasonRefreshReuse)
+			}
+
+			// Every access token of the family is refused once its session is checked
+			for i, pair := range pairs {
+				claims, err := authService.ValidateToken(pair.AccessToken)
+				if err != nil {
+					t.Fatalf("access token %d: %v", i, err)
+				}
+				if _, err := s.ValidateSession(claims, "198.51.100.7"); !errors.Is(err, ErrSessionRevoked) {
+					t.Errorf("access token %d: got error %v, want ErrSessionRevoked", i, err)
+				}
+			}
+		})
+	}
+}
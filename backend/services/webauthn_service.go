@@ -0,0 +1,282 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+var (
+	ErrWebAuthnCeremonyInvalid    = errors.New("passkey ceremony is invalid or has expired")
+	ErrWebAuthnVerificationFailed = errors.New("passkey verification failed")
+)
+
+const (
+	webAuthnCeremonyRegistration = "registration"
+	webAuthnCeremonyLogin        = "login"
+	webAuthnUserHandleSize       = 32
+)
+
+// WebAuthnOptions is the challenge sent to the browser, identified by the ceremony it belongs to
+type WebAuthnOptions struct {
+	CeremonyID string
+	Options    interface{}
+}
+
+type WebAuthnService struct {
+	verifier     *auth.WebAuthnVerifier
+	webauthnRepo *repository.WebAuthnRepository
+	adminRepo    *repository.AdminRepository
+}
+
+func NewWebAuthnService(verifier *auth.WebAuthnVerifier, webauthnRepo *repository.WebAuthnRepository, adminRepo *repository.AdminRepository) *WebAuthnService {
+	return &WebAuthnService{
+		verifier:     verifier,
+		webauthnRepo: webauthnRepo,
+		adminRepo:    adminRepo,
+	}
+}
+
+// BeginRegistration starts adding a passkey to the admin's account
+func (s *WebAuthnService) BeginRegistration(admin *models.Admin) (*WebAuthnOptions, error) {
+	user, err := s.loadUser(admin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every passkey for an admin shares one random user handle, which is what a discoverable
+	// login reports back to identify the account
+	if user.Handle == nil {
+		user.Handle = make([]byte, webAuthnUserHandleSize)
+		if _, err := rand.Read(user.Handle); err != nil {
+			return nil, fmt.Errorf("failed to generate user handle: %w", err)
+		}
+	}
+
+	creation, session, err := s.verifier.BeginRegistration(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey registration: %w", err)
+	}
+
+	ceremonyID, err := s.storeCeremony(&admin.ID, webAuthnCeremonyRegistration, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnOptions{CeremonyID: ceremonyID, Options: creation}, nil
+}
+
+// FinishRegistration verifies the authenticator's response and stores the new credential
+func (s *WebAuthnService) FinishRegistration(admin *models.Admin, ceremonyID, name string, response []byte) (*models.WebAuthnCredential, error) {
+	session, err := s.consumeCeremony(ceremonyID, webAuthnCeremonyRegistration, &admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.loadUser(admin)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Handle != nil && !bytes.Equal(user.Handle, session.UserID) {
+		return nil, ErrWebAuthnCeremonyInvalid
+	}
+	user.Handle = session.UserID
+
+	credential, err := s.verifier.FinishRegistration(user, *session, response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerificationFailed, err)
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return s.webauthnRepo.CreateCredential(&models.WebAuthnCredential{
+		AdminID:         admin.ID,
+		CredentialID:    credential.ID,
+		UserHandle:      user.Handle,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       int64(credential.Authenticator.SignCount),
+		Transports:      transports,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		Name:            name,
+	})
+}
+
+// BeginLogin starts a passkey login. It is not tied to an admin until the assertion comes back.
+func (s *WebAuthnService) BeginLogin() (*WebAuthnOptions, error) {
+	assertion, session, err := s.verifier.BeginLogin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey login: %w", err)
+	}
+
+	ceremonyID, err := s.storeCeremony(nil, webAuthnCeremonyLogin, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnOptions{CeremonyID: ceremonyID, Options: assertion}, nil
+}
+
+// FinishLogin verifies an assertion and returns the admin who owns the passkey. The admin is
+// returned alongside auth.ErrWebAuthnCloneWarning so the caller can attribute the event.
+func (s *WebAuthnService) FinishLogin(ceremonyID string, response []byte) (*models.Admin, error) {
+	session, err := s.consumeCeremony(ceremonyID, webAuthnCeremonyLogin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var admin *models.Admin
+	var stored *models.WebAuthnCredential
+	// Database errors are kept aside so an outage is not reported as a failed login
+	var lookupErr error
+	lookup := func(credentialID, userHandle []byte) (*auth.WebAuthnUser, error) {
+		credential, err := s.webauthnRepo.GetCredentialByCredentialID(credentialID)
+		if err != nil {
+			lookupErr = err
+			return nil, err
+		}
+		if credential == nil || !bytes.Equal(credential.UserHandle, userHandle) {
+			return nil, errors.New("unknown credential")
+		}
+
+		owner, err := s.adminRepo.GetAdminByID(credential.AdminID)
+		if err != nil {
+			lookupErr = err
+			return nil, err
+		}
+		if owner == nil || !owner.IsActive {
+			return nil, errors.New("account missing or disabled")
+		}
+
+		user, err := s.loadUser(owner)
+		if err != nil {
+			lookupErr = err
+			return nil, err
+		}
+
+		admin, stored = owner, credential
+		return user, nil
+	}
+
+	_, credential, err := s.verifier.FinishLogin(*session, response, lookup)
+	if lookupErr != nil {
+		return nil, fmt.Errorf("failed to look up passkey: %w", lookupErr)
+	}
+	if err != nil {
+		if errors.Is(err, auth.ErrWebAuthnCloneWarning) {
+			return admin, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerificationFailed, err)
+	}
+
+	if err := s.webauthnRepo.RecordCredentialUse(stored.ID, int64(credential.Authenticator.SignCount), credential.Flags.BackupState); err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+func (s *WebAuthnService) ListCredentials(adminID int) ([]models.WebAuthnCredential, error) {
+	return s.webauthnRepo.ListCredentials(adminID)
+}
+
+func (s *WebAuthnService) DeleteCredential(id, adminID int) (bool, error) {
+	return s.webauthnRepo.DeleteCredential(id, adminID)
+}
+
+// loadUser builds the library's view of an admin from their stored credentials
+func (s *WebAuthnService) loadUser(admin *models.Admin) (*auth.WebAuthnUser, error) {
+	stored, err := s.webauthnRepo.ListCredentials(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &auth.WebAuthnUser{
+		AdminID:     admin.ID,
+		Name:        admin.Username,
+		Credentials: make([]webauthn.Credential, 0, len(stored)),
+	}
+
+	for _, credential := range stored {
+		user.Handle = credential.UserHandle
+
+		transports := make([]protocol.AuthenticatorTransport, 0, len(credential.Transports))
+		for _, transport := range credential.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		user.Credentials = append(user.Credentials, webauthn.Credential{
+			ID:              credential.CredentialID,
+			PublicKey:       credential.PublicKey,
+			AttestationType: credential.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: credential.BackupEligible,
+				BackupState:    credential.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    credential.AAGUID,
+				SignCount: uint32(credential.SignCount),
+			},
+		})
+	}
+
+	return user, nil
+}
+
+func (s *WebAuthnService) storeCeremony(adminID *int, ceremonyType string, session *webauthn.SessionData) (string, error) {
+	sessionData, err := json.Marshal(session)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode WebAuthn session: %w", err)
+	}
+
+	ceremonyID, err := generateToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(auth.WebAuthnCeremonyTimeout)
+	if err := s.webauthnRepo.CreateCeremony(ceremonyID, adminID, ceremonyType, sessionData, expiresAt); err != nil {
+		return "", err
+	}
+
+	return ceremonyID, nil
+}
+
+// consumeCeremony loads and discards a ceremony, checking it was started by the expected admin
+func (s *WebAuthnService) consumeCeremony(ceremonyID, ceremonyType string, adminID *int) (*webauthn.SessionData, error) {
+	owner, sessionData, found, err := s.webauthnRepo.ConsumeCeremony(ceremonyID, ceremonyType)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrWebAuthnCeremonyInvalid
+	}
+
+	if adminID != nil && (owner == nil || *owner != *adminID) {
+		return nil, ErrWebAuthnCeremonyInvalid
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(sessionData, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode WebAuthn session: %w", err)
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Wildcard209/portfolio-webapplication/auth"
+	"github.com/Wildcard209/portfolio-webapplication/auth/webauthntest"
+	"github.com/Wildcard209/portfolio-webapplication/database/dbtest"
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/Wildcard209/portfolio-webapplication/repository"
+	"github.com/go-webauthn/webauthn/protocol"
+)
+
+const (
+	testWebAuthnRPID   = "portfolio.test"
+	testWebAuthnOrigin = "https://portfolio.test"
+)
+
+func newTestWebAuthnService(t *testing.T, db *sql.DB, adminRepo *repository.AdminRepository) *WebAuthnService {
+	t.Helper()
+
+	verifier, err := auth.NewWebAuthnVerifier(testWebAuthnRPID, "Portfolio Admin", []string{testWebAuthnOrigin})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	return NewWebAuthnService(verifier, repository.NewWebAuthnRepository(db), adminRepo)
+}
+
+// registerTestPasskey adds a passkey from a new software authenticator to the admin's account
+func registerTestPasskey(t *testing.T, s *WebAuthnService, admin *models.Admin) *webauthntest.Authenticator {
+	t.Helper()
+
+	authenticator, err := webauthntest.NewAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	options, err := s.BeginRegistration(admin)
+	if err != nil {
+		t.Fatalf("failed to begin registration: %v", err)
+	}
+
+	response, err := authenticator.Register(options.Options.(*protocol.CredentialCreation))
+	if err != nil {
+		t.Fatalf("authenticator failed to register: %v", err)
+	}
+
+	if _, err := s.FinishRegistration(admin, options.CeremonyID, "Test passkey", response); err != nil {
+		t.Fatalf("failed to finish registration: %v", err)
+	}
+	return authenticator
+}
+
+// loginWithTestPasskey runs a passkey login with the authenticator
+func loginWithTestPasskey(t *testing.T, s *WebAuthnService, authenticator *webauthntest.Authenticator) (*models.Admin, error) {
+	t.Helper()
+
+	options, err := s.BeginLogin()
+	if err != nil {
+		t.Fatalf("failed to begin login: %v", err)
+	}
+
+	response, err := authenticator.Login(options.Options.(*protocol.CredentialAssertion))
+	if err != nil {
+		t.Fatalf("authenticator failed to sign in: %v", err)
+	}
+
+	return s.FinishLogin(options.CeremonyID, response)
+}
+
+func TestWebAuthnServiceRegistrationAndLogin(t *testing.T) {
+	db := dbtest.Open(t)
+	s := newTestWebAuthnService(t, db, repository.NewAdminRepository(db))
+	admin := createTestAdmin(t, db, models.AdminRoleEditor)
+	authenticator := registerTestPasskey(t, s, admin)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		owner, err := loginWithTestPasskey(t, s, authenticator)
+		if err != nil {
+			t.Fatalf("login %d failed: %v", attempt, err)
+		}
+		if owner.ID != admin.ID {
+			t.Errorf("login %d signed in admin %d, want %d", attempt, owner.ID, admin.ID)
+		}
+	}
+
+	credentials, err := s.ListCredentials(admin.ID)
+	if err != nil {
+		t.Fatalf("failed to list credentials: %v", err)
+	}
+	if len(credentials) != 1 || credentials[0].SignCount != int64(authenticator.SignCount) {
+		t.Errorf("stored credentials %+v, want one with sign count %d", credentials, authenticator.SignCount)
+	}
+}
+
+func TestWebAuthnServiceWarnsOnSignCountRegression(t *testing.T) {
+	db := dbtest.Open(t)
+	s := newTestWebAuthnService(t, db, repository.NewAdminRepository(db))
+	admin := createTestAdmin(t, db, models.AdminRoleEditor)
+	authenticator := registerTestPasskey(t, s, admin)
+
+	if _, err := loginWithTestPasskey(t, s, authenticator); err != nil {
+		t.Fatalf("first login failed: %v", err)
+	}
+
+	// A copy of the key that has not seen the first login reports the same count again
+	authenticator.SignCount = 0
+
+	owner, err := loginWithTestPasskey(t, s, authenticator)
+	if !errors.Is(err, auth.ErrWebAuthnCloneWarning) {
+		t.Fatalf("got error %v, want auth.ErrWebAuthnCloneWarning", err)
+	}
+	if owner == nil || owner.ID != admin.ID {
+		t.Error("clone warning was not attributed to the passkey's owner")
+	}
+}
+
+func TestWebAuthnServiceReportsLookupErrorsAsServerErrors(t *testing.T) {
+	db := dbtest.Open(t)
+	admin := createTestAdmin(t, db, models.AdminRoleEditor)
+	authenticator := registerTestPasskey(t, newTestWebAuthnService(t, db, repository.NewAdminRepository(db)), admin)
+
+	// The credential is found, but loading its owner fails as it would during an outage
+	unavailable, err := sql.Open("pgx", os.Getenv("TEST_DATABASE_URL"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	unavailable.Close()
+	s := newTestWebAuthnService(t, db, repository.NewAdminRepository(unavailable))
+
+	owner, err := loginWithTestPasskey(t, s, authenticator)
+	if err == nil {
+		t.Fatal("login succeeded without loading the admin")
+	}
+	if errors.Is(err, ErrWebAuthnVerificationFailed) {
+		t.Errorf("lookup failure reported as a failed verification: %v", err)
+	}
+	if owner != nil {
+		t.Error("admin returned alongside a lookup failure")
+	}
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// metadataDir holds a JSON sidecar per object. Key segments may not start with a dot,
+// so neither it nor temporary files can collide with an object.
+const metadataDir = ".meta"
+
+// LocalStore keeps objects as plain files under a root directory, for development,
+// tests and single-host deployments that don't want to run MinIO
+type LocalStore struct {
+	root string
+}
+
+type localMetadata struct {
+	ContentType string            `json:"content_type"`
+	ETag        string            `json:"etag"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage driver requires STORAGE_LOCAL_DIR to be set")
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, metadataDir), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStore{root: root}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string, metadata map[string]string) error {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+
+	hash := md5.New()
+	if err := writeFileAtomic(objectPath, io.TeeReader(reader, hash)); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	encoded, err := json.Marshal(localMetadata{
+		ContentType: contentType,
+		ETag:        hex.EncodeToString(hash.Sum(nil)),
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode object metadata: %w", err)
+	}
+
+	if err := writeFileAtomic(metadataPath, strings.NewReader(string(encoded))); err != nil {
+		return fmt.Errorf("failed to write object metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	objectPath, _, _ := s.paths(key)
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return nil, ObjectInfo{}, translateFSError(err)
+	}
+
+	return file, info, nil
+}
+
+func (s *LocalStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	fileInfo, err := os.Stat(objectPath)
+	if err != nil {
+		return ObjectInfo{}, translateFSError(err)
+	}
+	if fileInfo.IsDir() {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+
+	var metadata localMetadata
+	if encoded, err := os.ReadFile(metadataPath); err == nil {
+		if err := json.Unmarshal(encoded, &metadata); err != nil {
+			return ObjectInfo{}, fmt.Errorf("failed to decode object metadata: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return ObjectInfo{}, fmt.Errorf("failed to read object metadata: %w", err)
+	}
+
+	return ObjectInfo{
+		Key:          key,
+		ContentType:  metadata.ContentType,
+		Size:         fileInfo.Size(),
+		ETag:         metadata.ETag,
+		LastModified: fileInfo.ModTime(),
+		Metadata:     metadata.Metadata,
+	}, nil
+}
+
+func (s *LocalStore) Remove(ctx context.Context, key string) error {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range []string{objectPath, metadataPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Dot-prefixed entries are metadata and in-progress writes, never objects
+		if path != s.root && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(relative); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// paths maps a key to its object and metadata file, rejecting keys that could escape the root
+func (s *LocalStore) paths(key string) (string, string, error) {
+	if key == "" || strings.Contains(key, "\\") || strings.HasPrefix(key, "/") {
+		return "", "", fmt.Errorf("invalid object key: %q", key)
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return "", "", fmt.Errorf("invalid object key: %q", key)
+		}
+	}
+
+	relative := filepath.FromSlash(key)
+	return filepath.Join(s.root, relative), filepath.Join(s.root, metadataDir, relative+".json"), nil
+}
+
+// writeFileAtomic writes to a temporary file in the target directory and renames it into
+// place so readers never observe a partially written object
+func writeFileAtomic(path string, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func translateFSError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/minio/minio-go/v7"
+)
+
+type MinioStore struct {
+	client     *minio.Client
+	bucketName string
+}
+
+func NewMinioStore(client *minio.Client, bucketName string) (*MinioStore, error) {
+	store := &MinioStore{
+		client:     client,
+		bucketName: bucketName,
+	}
+
+	// Ensure bucket exists
+	if err := store.ensureBucketExists(); err != nil {
+		log.Printf("Warning: Failed to ensure bucket exists: %v", err)
+	}
+
+	return store, nil
+}
+
+func (s *MinioStore) ensureBucketExists() error {
+	ctx := context.Background()
+
+	exists, err := s.client.BucketExists(ctx, s.bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check if bucket exists: %w", err)
+	}
+
+	if !exists {
+		err = s.client.MakeBucket(ctx, s.bucketName, minio.MakeBucketOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create bucket: %w", err)
+		}
+		log.Printf("Created bucket: %s", s.bucketName)
+	}
+
+	return nil
+}
+
+func (s *MinioStore) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string, metadata map[string]string) error {
+	_, err := s.client.PutObject(ctx, s.bucketName, key, reader, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		UserMetadata: metadata,
+	})
+	return err
+}
+
+func (s *MinioStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	object, err := s.client.GetObject(ctx, s.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, translateMinioError(err)
+	}
+
+	// GetObject is lazy; Stat performs the request so a missing object is reported here
+	objectInfo, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, translateMinioError(err)
+	}
+
+	return object, toObjectInfo(objectInfo), nil
+}
+
+func (s *MinioStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	objectInfo, err := s.client.StatObject(ctx, s.bucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, translateMinioError(err)
+	}
+
+	return toObjectInfo(objectInfo), nil
+}
+
+func (s *MinioStore) Remove(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucketName, key, minio.RemoveObjectOptions{})
+}
+
+func (s *MinioStore) List(ctx context.Context, prefix string) ([]string, error) {
+	objects := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	var keys []string
+	for object := range objects {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+
+	return keys, nil
+}
+
+func toObjectInfo(objectInfo minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          objectInfo.Key,
+		ContentType:  objectInfo.ContentType,
+		Size:         objectInfo.Size,
+		ETag:         objectInfo.ETag,
+		LastModified: objectInfo.LastModified,
+		Metadata:     objectInfo.UserMetadata,
+	}
+}
+
+func translateMinioError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
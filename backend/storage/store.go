@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Wildcard209/portfolio-webapplication/config"
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	DriverMinio = "minio"
+	DriverLocal = "local"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object. Metadata holds the user metadata supplied on Put.
+type ObjectInfo struct {
+	Key          string
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	Metadata     map[string]string
+}
+
+// ObjectStore is a flat key/value blob store. Keys may contain "/" to group objects,
+// but a key must never also be used as the prefix of another key.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string, metadata map[string]string) error
+	// Get opens an object for streaming; the caller must close the returned reader
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Remove deletes an object; removing a missing key is not an error
+	Remove(ctx context.Context, key string) error
+	// List returns the keys of every object whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// NewObjectStore builds the store selected by cfg.Driver. The MinIO driver requires an
+// initialised client.
+func NewObjectStore(cfg *config.StorageConfig, minioClient *minio.Client) (ObjectStore, error) {
+	switch cfg.Driver {
+	case DriverMinio, "":
+		if minioClient == nil {
+			return nil, fmt.Errorf("minio storage driver requires a configured MinIO client")
+		}
+		return NewMinioStore(minioClient, cfg.Bucket)
+	case DriverLocal:
+		return NewLocalStore(cfg.LocalDir)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}
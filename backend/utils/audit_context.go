@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	auditActorKey  = "auditActor"
+	auditChangeKey = "auditChange"
+)
+
+// AuditChange is what a handler reports about the change it made, for the audit log. Empty
+// fields are filled in from the route by the audit middleware.
+type AuditChange struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+}
+
+// SetAuditChange describes the change the current request made. Calling it again replaces the
+// previous description.
+func SetAuditChange(c *gin.Context, change AuditChange) {
+	c.Set(auditChangeKey, &change)
+}
+
+// GetAuditChange returns the change set by the handler, or nil if it did not describe one
+func GetAuditChange(c *gin.Context) *AuditChange {
+	if value, exists := c.Get(auditChangeKey); exists {
+		if change, ok := value.(*AuditChange); ok {
+			return change
+		}
+	}
+	return nil
+}
+
+// SetAuditActor records who made the request on routes that authenticate the admin themselves,
+// such as login and token refresh, rather than through the auth middleware
+func SetAuditActor(c *gin.Context, admin *models.Admin) {
+	c.Set(auditActorKey, admin)
+}
+
+// GetAuditActor returns the admin behind the request, from the auth middleware or SetAuditActor
+func GetAuditActor(c *gin.Context) *models.Admin {
+	for _, key := range []string{"admin", auditActorKey} {
+		if value, exists := c.Get(key); exists {
+			if admin, ok := value.(*models.Admin); ok {
+				return admin
+			}
+		}
+	}
+	return nil
+}
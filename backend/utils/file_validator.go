@@ -25,6 +25,8 @@ var allowedFileSignatures = []FileSignature{
 	{MimeType: "image/png", Extension: ".png", Signature: []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}},
 	{MimeType: "image/gif", Extension: ".gif", Signature: []byte{0x47, 0x49, 0x46, 0x38}},
 	{MimeType: "image/webp", Extension: ".webp", Signature: []byte{0x52, 0x49, 0x46, 0x46}}, // RIFF header, WebP has additional checks
+	{MimeType: "image/x-icon", Extension: ".ico", Signature: []byte{0x00, 0x00, 0x01, 0x00}},
+	{MimeType: "application/pdf", Extension: ".pdf", Signature: []byte{0x25, 0x50, 0x44, 0x46, 0x2D}},
 }
 
 // FileValidator provides comprehensive file validation
@@ -121,13 +123,16 @@ func (fv *FileValidator) detectContentType(file multipart.File) (string, error)
 		return "", fmt.Errorf("failed to read file header: %w", err)
 	}
 
-	buffer = buffer[:n]
+	return DetectFileType(buffer[:n])
+}
 
+// DetectFileType identifies a file from its magic bytes, among the types uploads may have
+func DetectFileType(data []byte) (string, error) {
 	for _, sig := range allowedFileSignatures {
-		if len(buffer) >= len(sig.Signature) {
-			if bytes.HasPrefix(buffer, sig.Signature) {
+		if len(data) >= len(sig.Signature) {
+			if bytes.HasPrefix(data, sig.Signature) {
 				if sig.MimeType == "image/webp" {
-					if len(buffer) >= 12 && string(buffer[8:12]) == "WEBP" {
+					if len(data) >= 12 && string(data[8:12]) == "WEBP" {
 						return sig.MimeType, nil
 					}
 				} else {
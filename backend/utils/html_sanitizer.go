@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// HTMLSanitizer rewrites untrusted HTML against an allowlist of tags and attributes
+// so that the result can be rendered under the API's Content-Security-Policy
+type HTMLSanitizer struct {
+	allowedTags     map[string][]string
+	droppedContent  map[string]bool
+	classPattern    *regexp.Regexp
+	idPattern       *regexp.Regexp
+	allowedSchemes  []string
+	externalLinkRel string
+}
+
+func NewHTMLSanitizer() *HTMLSanitizer {
+	return &HTMLSanitizer{
+		allowedTags: map[string][]string{
+			"p": nil, "br": nil, "hr": nil,
+			"h1": {"id"}, "h2": {"id"}, "h3": {"id"}, "h4": {"id"}, "h5": {"id"}, "h6": {"id"},
+			"strong": nil, "em": nil, "del": nil, "blockquote": nil,
+			"ul": nil, "ol": {"start"}, "li": nil,
+			"pre": nil, "code": {"class"}, "span": {"class"},
+			"a":     {"href", "title"},
+			"img":   {"src", "alt", "title", "width", "height"},
+			"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+			"th": {"align"}, "td": {"align"},
+			"nav": {"class"},
+		},
+		droppedContent: map[string]bool{
+			"script": true, "style": true, "iframe": true, "object": true,
+			"embed": true, "noscript": true, "template": true, "svg": true, "math": true,
+		},
+		classPattern:    regexp.MustCompile(`^(language-[a-zA-Z0-9+#_-]+|toc)$`),
+		idPattern:       regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+		allowedSchemes:  []string{"http", "https", "mailto"},
+		externalLinkRel: "nofollow noopener noreferrer",
+	}
+}
+
+func (hs *HTMLSanitizer) Sanitize(input string) string {
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(input))
+
+	var output strings.Builder
+	var openTags []string
+	skipDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == xhtml.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return ""
+			}
+			break
+		}
+
+		token := tokenizer.Token()
+		tagName := strings.ToLower(token.Data)
+
+		switch tokenType {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if hs.droppedContent[tagName] {
+				if tokenType == xhtml.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+
+			allowedAttrs, ok := hs.allowedTags[tagName]
+			if !ok {
+				continue
+			}
+
+			output.WriteString("<" + tagName)
+			output.WriteString(hs.sanitizeAttributes(tagName, token.Attr, allowedAttrs))
+
+			if tokenType == xhtml.SelfClosingTagToken || isVoidElement(tagName) {
+				output.WriteString(" />")
+				continue
+			}
+
+			output.WriteString(">")
+			openTags = append(openTags, tagName)
+
+		case xhtml.EndTagToken:
+			if hs.droppedContent[tagName] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+
+			for i := len(openTags) - 1; i >= 0; i-- {
+				if openTags[i] != tagName {
+					continue
+				}
+				for j := len(openTags) - 1; j >= i; j-- {
+					output.WriteString("</" + openTags[j] + ">")
+				}
+				openTags = openTags[:i]
+				break
+			}
+
+		case xhtml.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			output.WriteString(html.EscapeString(token.Data))
+		}
+	}
+
+	for i := len(openTags) - 1; i >= 0; i-- {
+		output.WriteString("</" + openTags[i] + ">")
+	}
+
+	return output.String()
+}
+
+func (hs *HTMLSanitizer) sanitizeAttributes(tagName string, attrs []xhtml.Attribute, allowed []string) string {
+	var result strings.Builder
+	hasExternalLink := false
+
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+
+		// Event handlers are never allowed, regardless of the tag allowlist
+		if strings.HasPrefix(key, "on") || !contains(allowed, key) {
+			continue
+		}
+
+		value := strings.TrimSpace(attr.Val)
+		if containsXSSPattern(value) {
+			continue
+		}
+
+		switch key {
+		case "href", "src":
+			if !hs.isSafeURL(value) {
+				continue
+			}
+			if key == "href" && isAbsoluteURL(value) {
+				hasExternalLink = true
+			}
+		case "class":
+			if !hs.classPattern.MatchString(value) {
+				continue
+			}
+		case "id":
+			if !hs.idPattern.MatchString(value) {
+				continue
+			}
+		case "align":
+			value = strings.ToLower(value)
+			if value != "left" && value != "right" && value != "center" {
+				continue
+			}
+		case "start", "width", "height":
+			if strings.Trim(value, "0123456789") != "" {
+				continue
+			}
+		}
+
+		result.WriteString(" " + key + "=\"" + html.EscapeString(value) + "\"")
+	}
+
+	if tagName == "a" && hasExternalLink {
+		result.WriteString(" rel=\"" + hs.externalLinkRel + "\"")
+	}
+
+	return result.String()
+}
+
+func (hs *HTMLSanitizer) isSafeURL(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	if strings.HasPrefix(value, "#") || (strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "//")) {
+		return true
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	if parsed.Scheme == "" {
+		return !strings.Contains(value, ":")
+	}
+
+	return contains(hs.allowedSchemes, strings.ToLower(parsed.Scheme))
+}
+
+func containsXSSPattern(value string) bool {
+	normalized := strings.ToLower(value)
+	normalized = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == 0 {
+			return -1
+		}
+		return r
+	}, normalized)
+
+	for _, pattern := range xssPatterns {
+		if strings.Contains(normalized, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAbsoluteURL(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func isVoidElement(tagName string) bool {
+	return tagName == "br" || tagName == "hr" || tagName == "img"
+}
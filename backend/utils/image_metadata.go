@@ -0,0 +1,435 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// ImageMetadataOptions controls which metadata survives StripImageMetadata. Everything
+// else (EXIF, XMP, IPTC, comments, text chunks) is always removed because it can carry
+// GPS coordinates, device serial numbers and editing history.
+type ImageMetadataOptions struct {
+	// KeepColorProfile retains embedded ICC profiles so wide-gamut photos render correctly
+	KeepColorProfile bool
+	// KeepOrientation retains the EXIF orientation as a minimal EXIF block with no other tags
+	KeepOrientation bool
+}
+
+var (
+	pngSignature     = []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	exifHeader       = []byte("Exif\x00\x00")
+	iccProfileHeader = []byte("ICC_PROFILE\x00")
+	adobeHeader      = []byte("Adobe")
+	jfifHeader       = []byte("JFIF\x00")
+)
+
+var errMalformedImage = errors.New("malformed image data")
+
+const exifOrientationTag = 0x0112
+
+// StripImageMetadata removes privacy-sensitive metadata from JPEG, PNG and WebP files
+// without re-encoding the image data. Other formats are returned unchanged.
+func StripImageMetadata(data []byte, options ImageMetadataOptions) ([]byte, error) {
+	switch {
+	case isJPEG(data):
+		return stripJPEGMetadata(data, options)
+	case isPNG(data):
+		return stripPNGMetadata(data, options)
+	case isWebP(data):
+		return stripWebPMetadata(data, options)
+	default:
+		return data, nil
+	}
+}
+
+// ImageOrientation returns the EXIF orientation (1-8) of a JPEG, PNG or WebP file,
+// or 1 when the file has no orientation tag
+func ImageOrientation(data []byte) int {
+	var tiff []byte
+
+	switch {
+	case isJPEG(data):
+		_ = walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+			payload := segment[4:]
+			if marker == 0xE1 && bytes.HasPrefix(payload, exifHeader) {
+				tiff = payload[len(exifHeader):]
+				return false
+			}
+			return true
+		})
+	case isPNG(data):
+		_ = walkPNGChunks(data, func(chunkType string, chunk []byte) bool {
+			if chunkType == "eXIf" {
+				tiff = chunk[8 : len(chunk)-4]
+				return false
+			}
+			return true
+		})
+	case isWebP(data):
+		_ = walkWebPChunks(data, func(fourCC string, chunk []byte) bool {
+			if fourCC == "EXIF" {
+				tiff = bytes.TrimPrefix(webPChunkPayload(chunk), exifHeader)
+				return false
+			}
+			return true
+		})
+	}
+
+	if orientation := exifOrientation(tiff); orientation > 0 {
+		return orientation
+	}
+	return 1
+}
+
+func stripJPEGMetadata(data []byte, options ImageMetadataOptions) ([]byte, error) {
+	orientation := 1
+	if options.KeepOrientation {
+		orientation = ImageOrientation(data)
+	}
+
+	output := bytes.NewBuffer(make([]byte, 0, len(data)))
+	output.Write(data[:2])
+	orientationWritten := orientation <= 1
+
+	writeOrientation := func() {
+		if orientationWritten {
+			return
+		}
+		payload := append(append([]byte{}, exifHeader...), minimalExifOrientation(orientation)...)
+		output.Write([]byte{0xFF, 0xE1})
+		output.Write(binary.BigEndian.AppendUint16(nil, uint16(len(payload)+2)))
+		output.Write(payload)
+		orientationWritten = true
+	}
+
+	err := walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		if marker == 0xDA {
+			// Start of scan: everything from here on is image data
+			writeOrientation()
+			output.Write(segment)
+			return false
+		}
+
+		payload := segment[4:]
+		keep := true
+
+		switch {
+		case marker == 0xE0:
+			// JFIF is required by some decoders; JFXX thumbnails are dropped
+			keep = bytes.HasPrefix(payload, jfifHeader)
+		case marker == 0xE2:
+			keep = options.KeepColorProfile && bytes.HasPrefix(payload, iccProfileHeader)
+		case marker == 0xEE:
+			// The Adobe segment describes the color transform and is needed to decode CMYK images
+			keep = bytes.HasPrefix(payload, adobeHeader)
+		case marker >= 0xE1 && marker <= 0xEF, marker == 0xFE:
+			keep = false
+		}
+
+		// The EXIF block belongs directly after SOI, or after the JFIF segment when present
+		if keep && marker == 0xE0 {
+			output.Write(segment)
+			writeOrientation()
+			return true
+		}
+
+		writeOrientation()
+		if keep {
+			output.Write(segment)
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+func stripPNGMetadata(data []byte, options ImageMetadataOptions) ([]byte, error) {
+	orientation := 1
+	if options.KeepOrientation {
+		orientation = ImageOrientation(data)
+	}
+
+	output := bytes.NewBuffer(make([]byte, 0, len(data)))
+	output.Write(pngSignature)
+
+	err := walkPNGChunks(data, func(chunkType string, chunk []byte) bool {
+		switch chunkType {
+		case "tEXt", "zTXt", "iTXt", "eXIf", "tIME":
+			return true
+		case "iCCP":
+			if !options.KeepColorProfile {
+				return true
+			}
+		}
+
+		output.Write(chunk)
+
+		if chunkType == "IHDR" && orientation > 1 {
+			writePNGChunk(output, "eXIf", minimalExifOrientation(orientation))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+func stripWebPMetadata(data []byte, options ImageMetadataOptions) ([]byte, error) {
+	const (
+		vp8xICCFlag  = 0x20
+		vp8xEXIFFlag = 0x08
+		vp8xXMPFlag  = 0x04
+	)
+
+	orientation := 1
+	if options.KeepOrientation {
+		orientation = ImageOrientation(data)
+	}
+
+	var chunks [][]byte
+	var vp8x []byte
+	err := walkWebPChunks(data, func(fourCC string, chunk []byte) bool {
+		switch fourCC {
+		case "EXIF", "XMP ":
+			return true
+		case "ICCP":
+			if !options.KeepColorProfile {
+				return true
+			}
+		case "VP8X":
+			chunk = append([]byte{}, chunk...)
+			vp8x = chunk
+		}
+		chunks = append(chunks, chunk)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Simple (non-extended) WebP files cannot carry metadata
+	if vp8x == nil {
+		return data, nil
+	}
+
+	// The chunk header is followed by a 10 byte payload holding the flags and canvas size
+	if len(vp8x) < 8+10 {
+		return nil, errMalformedImage
+	}
+
+	flags := vp8x[8] &^ (vp8xEXIFFlag | vp8xXMPFlag)
+	if !options.KeepColorProfile {
+		flags &^= vp8xICCFlag
+	}
+	if orientation > 1 {
+		flags |= vp8xEXIFFlag
+		var exifChunk bytes.Buffer
+		writeWebPChunk(&exifChunk, "EXIF", minimalExifOrientation(orientation))
+		chunks = append(chunks, exifChunk.Bytes())
+	}
+	vp8x[8] = flags
+
+	body := bytes.NewBufferString("WEBP")
+	for _, chunk := range chunks {
+		body.Write(chunk)
+	}
+
+	output := bytes.NewBuffer(make([]byte, 0, body.Len()+8))
+	output.WriteString("RIFF")
+	output.Write(binary.LittleEndian.AppendUint32(nil, uint32(body.Len())))
+	output.Write(body.Bytes())
+
+	return output.Bytes(), nil
+}
+
+// walkJPEGSegments calls fn for each marker segment up to and including SOS. The segment
+// slice includes the marker and length bytes; for SOS it extends to the end of the file.
+func walkJPEGSegments(data []byte, fn func(marker byte, segment []byte) bool) error {
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xFF {
+			return errMalformedImage
+		}
+
+		// Markers may be preceded by any number of fill bytes
+		for i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) {
+			return errMalformedImage
+		}
+
+		marker := data[i+1]
+		if marker == 0xD9 {
+			return nil
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return errMalformedImage
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:i+4]))
+		if end > len(data) || end < i+4 {
+			return errMalformedImage
+		}
+
+		if marker == 0xDA {
+			fn(marker, data[i:])
+			return nil
+		}
+
+		if !fn(marker, data[i:end]) {
+			return nil
+		}
+		i = end
+	}
+
+	return errMalformedImage
+}
+
+// walkPNGChunks calls fn for each chunk; the chunk slice includes length, type and CRC
+func walkPNGChunks(data []byte, fn func(chunkType string, chunk []byte) bool) error {
+	i := len(pngSignature)
+	for i+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) || end < i {
+			return errMalformedImage
+		}
+
+		chunkType := string(data[i+4 : i+8])
+		if !fn(chunkType, data[i:end]) {
+			return nil
+		}
+		if chunkType == "IEND" {
+			return nil
+		}
+		i = end
+	}
+
+	return errMalformedImage
+}
+
+// walkWebPChunks calls fn for each RIFF chunk; the chunk slice includes the header and padding
+func walkWebPChunks(data []byte, fn func(fourCC string, chunk []byte) bool) error {
+	i := 12
+	for i+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2
+		if size < 0 || end < i {
+			return errMalformedImage
+		}
+		if end > len(data) {
+			// Tolerate a missing pad byte on the final chunk
+			if i+8+size != len(data) {
+				return errMalformedImage
+			}
+			end = len(data)
+		}
+
+		if !fn(string(data[i:i+4]), data[i:end]) {
+			return nil
+		}
+		i = end
+	}
+
+	return nil
+}
+
+func webPChunkPayload(chunk []byte) []byte {
+	size := int(binary.LittleEndian.Uint32(chunk[4:8]))
+	return chunk[8 : 8+size]
+}
+
+func writePNGChunk(output *bytes.Buffer, chunkType string, payload []byte) {
+	output.Write(binary.BigEndian.AppendUint32(nil, uint32(len(payload))))
+	typeAndPayload := append([]byte(chunkType), payload...)
+	output.Write(typeAndPayload)
+	output.Write(binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(typeAndPayload)))
+}
+
+func writeWebPChunk(output *bytes.Buffer, fourCC string, payload []byte) {
+	output.WriteString(fourCC)
+	output.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(payload))))
+	output.Write(payload)
+	if len(payload)%2 == 1 {
+		output.WriteByte(0)
+	}
+}
+
+// minimalExifOrientation builds a big-endian TIFF structure holding only the orientation tag
+func minimalExifOrientation(orientation int) []byte {
+	tiff := []byte{'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08}
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = binary.BigEndian.AppendUint16(tiff, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0)
+	return tiff
+}
+
+// exifOrientation reads the orientation tag from IFD0 of a TIFF structure, returning 0 if absent
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var byteOrder binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		byteOrder = binary.LittleEndian
+	case "MM":
+		byteOrder = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifdOffset := int(byteOrder.Uint32(tiff[4:8]))
+	if ifdOffset < 8 || ifdOffset+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(byteOrder.Uint16(tiff[ifdOffset:]))
+	for i := 0; i < entries; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if byteOrder.Uint16(tiff[entry:]) != exifOrientationTag || byteOrder.Uint16(tiff[entry+2:]) != 3 {
+			continue
+		}
+		orientation := int(byteOrder.Uint16(tiff[entry+8:]))
+		if orientation >= 1 && orientation <= 8 {
+			return orientation
+		}
+		return 0
+	}
+
+	return 0
+}
+
+func isJPEG(data []byte) bool {
+	return len(data) > 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF
+}
+
+func isPNG(data []byte) bool {
+	return bytes.HasPrefix(data, pngSignature)
+}
+
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
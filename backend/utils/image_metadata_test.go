@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// webPFile wraps RIFF chunks, each given as a FourCC and payload, into a WebP file
+func webPFile(chunks ...[2]string) []byte {
+	body := []byte("WEBP")
+	for _, chunk := range chunks {
+		body = append(body, chunk[0]...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(chunk[1])))
+		body = append(body, chunk[1]...)
+		if len(chunk[1])%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	return append(data, body...)
+}
+
+func TestStripWebPMetadataRejectsTruncatedVP8X(t *testing.T) {
+	for _, payload := range []string{"", "\x08", "\x08\x00\x00\x00"} {
+		data := webPFile([2]string{"VP8X", payload}, [2]string{"EXIF", "private"})
+
+		if _, err := StripImageMetadata(data, ImageMetadataOptions{}); !errors.Is(err, errMalformedImage) {
+			t.Errorf("VP8X payload of %d bytes: got error %v, want errMalformedImage", len(payload), err)
+		}
+	}
+}
+
+func TestStripWebPMetadataClearsVP8XFlags(t *testing.T) {
+	vp8x := "\x2c\x00\x00\x00\x00\x00\x00\x00\x00\x00"
+	data := webPFile([2]string{"VP8X", vp8x}, [2]string{"EXIF", "private"}, [2]string{"VP8L", "pixels"})
+
+	stripped, err := StripImageMetadata(data, ImageMetadataOptions{})
+	if err != nil {
+		t.Fatalf("StripImageMetadata: %v", err)
+	}
+
+	var sawEXIF bool
+	var flags byte
+	if err := walkWebPChunks(stripped, func(fourCC string, chunk []byte) bool {
+		switch fourCC {
+		case "EXIF":
+			sawEXIF = true
+		case "VP8X":
+			flags = chunk[8]
+		}
+		return true
+	}); err != nil {
+		t.Fatalf("walkWebPChunks: %v", err)
+	}
+
+	if sawEXIF {
+		t.Error("EXIF chunk was not removed")
+	}
+	if flags != 0 {
+		t.Errorf("VP8X flags = %#x, want 0", flags)
+	}
+}
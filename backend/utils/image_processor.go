@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	ImageFormatWebP = "webp"
+)
+
+// ErrImageTooLarge is returned for images with more pixels than the processor will decode
+var ErrImageTooLarge = errors.New("image dimensions exceed the allowed pixel count")
+
+var imageFormatContentTypes = map[string]string{
+	ImageFormatJPEG: "image/jpeg",
+	ImageFormatPNG:  "image/png",
+	ImageFormatWebP: "image/webp",
+}
+
+// ImageVariant is a resized copy of an uploaded image
+type ImageVariant struct {
+	Width       int
+	Height      int
+	Format      string
+	ContentType string
+	Data        []byte
+}
+
+// ImageProcessor produces downscaled variants of uploaded images so that clients
+// can fetch a size appropriate to their viewport
+type ImageProcessor struct {
+	widths       []int
+	webpVariants bool
+	jpegQuality  int
+	maxPixels    int
+}
+
+func NewImageProcessor(widths []int, webpVariants bool, jpegQuality, maxPixels int) *ImageProcessor {
+	return &ImageProcessor{
+		widths:       widths,
+		webpVariants: webpVariants,
+		jpegQuality:  jpegQuality,
+		maxPixels:    maxPixels,
+	}
+}
+
+// CanResize reports whether variants can be generated for the content type.
+// GIFs are excluded so animations are never flattened to their first frame.
+func (ip *ImageProcessor) CanResize(contentType string) bool {
+	return contentType == "image/jpeg" || contentType == "image/png" || contentType == "image/webp"
+}
+
+// GenerateVariants decodes the image and returns one variant per configured width that is
+// narrower than the displayed original. Opaque images are encoded as JPEG and images with transparency
+// as PNG; when WebP variants are enabled a WebP copy is added if it is smaller. Images larger
+// than the pixel limit are rejected with ErrImageTooLarge before anything is decoded.
+func (ip *ImageProcessor) GenerateVariants(data []byte) ([]ImageVariant, error) {
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if int64(imageConfig.Width)*int64(imageConfig.Height) > int64(ip.maxPixels) {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, imageConfig.Width, imageConfig.Height)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := source.Bounds()
+	primaryFormat := ImageFormatJPEG
+	if !isOpaque(source) {
+		primaryFormat = ImageFormatPNG
+	}
+
+	// Variants never carry metadata, so any EXIF orientation is applied to the pixels
+	orientation := ImageOrientation(data)
+	displayWidth, displayHeight := bounds.Dx(), bounds.Dy()
+	if orientation >= 5 {
+		displayWidth, displayHeight = displayHeight, displayWidth
+	}
+
+	var variants []ImageVariant
+	for _, width := range ip.widths {
+		if width >= displayWidth {
+			break
+		}
+
+		height := (displayHeight*width + displayWidth/2) / displayWidth
+		if height < 1 {
+			height = 1
+		}
+
+		scaledWidth, scaledHeight := width, height
+		if orientation >= 5 {
+			scaledWidth, scaledHeight = height, width
+		}
+
+		scaled := image.NewNRGBA(image.Rect(0, 0, scaledWidth, scaledHeight))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), source, bounds, draw.Src, nil)
+		resized := applyOrientation(scaled, orientation)
+
+		primary, err := ip.encode(resized, primaryFormat)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, newImageVariant(width, height, primaryFormat, primary))
+
+		if !ip.webpVariants {
+			continue
+		}
+
+		webp, err := ip.encode(resized, ImageFormatWebP)
+		if err != nil {
+			return nil, err
+		}
+		if len(webp) < len(primary) {
+			variants = append(variants, newImageVariant(width, height, ImageFormatWebP, webp))
+		}
+	}
+
+	return variants, nil
+}
+
+func (ip *ImageProcessor) encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch format {
+	case ImageFormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: ip.jpegQuality})
+	case ImageFormatPNG:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&buf, img)
+	case ImageFormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s image: %w", format, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImageFormatContentType returns the MIME type for a variant format
+func ImageFormatContentType(format string) string {
+	return imageFormatContentTypes[format]
+}
+
+func newImageVariant(width, height int, format string, data []byte) ImageVariant {
+	return ImageVariant{
+		Width:       width,
+		Height:      height,
+		Format:      format,
+		ContentType: imageFormatContentTypes[format],
+		Data:        data,
+	}
+}
+
+// applyOrientation transforms an image so that it displays upright for the given EXIF orientation
+func applyOrientation(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+
+			srcOffset := img.PixOffset(x, y)
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], img.Pix[srcOffset:srcOffset+4])
+		}
+	}
+
+	return dst
+}
+
+func isOpaque(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return opaque.Opaque()
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"fmt"
 	"html"
+	"net/mail"
+	"net/url"
 	"regexp"
 	"strings"
 	"unicode"
@@ -139,6 +141,83 @@ func (is *InputSanitizer) ValidatePassword(password string) error {
 	return nil
 }
 
+func (is *InputSanitizer) ValidateEmail(email string) error {
+	if email == "" {
+		return NewValidationError("email", "is required")
+	}
+
+	if len(email) > 254 {
+		return NewValidationError("email", "must not exceed 254 characters")
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || address.Name != "" {
+		return NewValidationError("email", "must be a valid email address")
+	}
+
+	if !strings.Contains(email[strings.LastIndex(email, "@")+1:], ".") {
+		return NewValidationError("email", "must be a valid email address")
+	}
+
+	return nil
+}
+
+func (is *InputSanitizer) SanitizeSlug(input string) string {
+	if input == "" {
+		return ""
+	}
+
+	sanitized := strings.ToLower(strings.TrimSpace(input))
+
+	separatorPattern := regexp.MustCompile(`[^a-z0-9]+`)
+	sanitized = separatorPattern.ReplaceAllString(sanitized, "-")
+	sanitized = strings.Trim(sanitized, "-")
+
+	if len(sanitized) > 120 {
+		sanitized = strings.TrimRight(sanitized[:120], "-")
+	}
+
+	return sanitized
+}
+
+func (is *InputSanitizer) ValidateSlug(slug string) error {
+	if slug == "" {
+		return NewValidationError("slug", "is required")
+	}
+
+	if len(slug) > 120 {
+		return NewValidationError("slug", "must not exceed 120 characters")
+	}
+
+	slugPattern := regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	if !slugPattern.MatchString(slug) {
+		return NewValidationError("slug", "can only contain lowercase letters, numbers, and single hyphens")
+	}
+
+	return nil
+}
+
+func (is *InputSanitizer) ValidateURL(input string, fieldName string) error {
+	if err := is.ValidateString(input, fieldName, 1, 2048); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(input, "/") && !strings.HasPrefix(input, "//") {
+		return nil
+	}
+
+	parsed, err := url.Parse(input)
+	if err != nil || parsed.Host == "" {
+		return NewValidationError(fieldName, "must be a valid URL")
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return NewValidationError(fieldName, "must use http or https")
+	}
+
+	return nil
+}
+
 func removeControlCharacters(input string) string {
 	var result strings.Builder
 	for _, r := range input {
@@ -154,6 +233,12 @@ func removeControlCharacters(input string) string {
 	return result.String()
 }
 
+var xssPatterns = []string{
+	"<script", "</script>", "javascript:", "vbscript:",
+	"onload=", "onerror=", "onclick=", "onmouseover=",
+	"eval(", "expression(", "url(javascript",
+}
+
 func containsDangerousPatterns(input string) bool {
 	lowerInput := strings.ToLower(input)
 
@@ -163,12 +248,6 @@ func containsDangerousPatterns(input string) bool {
 		"execute(", "sp_", "xp_", "--;", "/*", "*/",
 	}
 
-	xssPatterns := []string{
-		"<script", "</script>", "javascript:", "vbscript:",
-		"onload=", "onerror=", "onclick=", "onmouseover=",
-		"eval(", "expression(", "url(javascript",
-	}
-
 	for _, pattern := range sqlPatterns {
 		if strings.Contains(lowerInput, pattern) {
 			return true
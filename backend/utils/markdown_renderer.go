@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Wildcard209/portfolio-webapplication/models"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+type RenderedMarkdown struct {
+	HTML            string
+	TableOfContents models.TableOfContents
+}
+
+// MarkdownRenderer converts Markdown into sanitized HTML with heading anchors,
+// language-tagged code blocks and a table of contents
+type MarkdownRenderer struct {
+	markdown      goldmark.Markdown
+	htmlSanitizer *HTMLSanitizer
+	maxTOCLevel   int
+}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(
+				extension.Strikethrough,
+				extension.Linkify,
+				extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+			),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		),
+		htmlSanitizer: NewHTMLSanitizer(),
+		maxTOCLevel:   3,
+	}
+}
+
+func (mr *MarkdownRenderer) Render(source string) (*RenderedMarkdown, error) {
+	sourceBytes := []byte(source)
+	document := mr.markdown.Parser().Parse(text.NewReader(sourceBytes))
+
+	var buf bytes.Buffer
+	if err := mr.markdown.Renderer().Render(&buf, sourceBytes, document); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return &RenderedMarkdown{
+		HTML:            mr.htmlSanitizer.Sanitize(buf.String()),
+		TableOfContents: mr.buildTableOfContents(document, sourceBytes),
+	}, nil
+}
+
+func (mr *MarkdownRenderer) buildTableOfContents(document ast.Node, source []byte) models.TableOfContents {
+	entries := models.TableOfContents{}
+
+	_ = ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		if heading.Level > mr.maxTOCLevel {
+			return ast.WalkSkipChildren, nil
+		}
+
+		id, hasID := heading.AttributeString("id")
+		idBytes, isBytes := id.([]byte)
+		if !hasID || !isBytes {
+			return ast.WalkSkipChildren, nil
+		}
+
+		entries = append(entries, models.TOCEntry{
+			Level: heading.Level,
+			ID:    string(idBytes),
+			Text:  strings.TrimSpace(headingText(heading, source)),
+		})
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	return entries
+}
+
+func headingText(node ast.Node, source []byte) string {
+	var builder strings.Builder
+
+	_ = ast.Walk(node, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := child.(type) {
+		case *ast.Text:
+			builder.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				builder.WriteString(" ")
+			}
+		case *ast.String:
+			builder.Write(n.Value)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return builder.String()
+}
@@ -9,9 +9,9 @@ import styles from './HeroBanner.module.scss';
 export default function HeroBanner() {
   const { isAuthenticated } = useAuth();
 
-  const { data: assetInfo, refetch: refetchAssetInfo } = useApi<{ hero_banner_available: boolean }>(
-    '/assets/info'
-  );
+  const { data: assetInfo, refetch: refetchAssetInfo } = useApi<{
+    assets: { slot: string; available: boolean }[];
+  }>('/assets/info');
 
   const {
     uploadFile,
@@ -24,7 +24,11 @@ export default function HeroBanner() {
   const [previewUrl, setPreviewUrl] = useState<string>('');
   const fileInputRef = useRef<HTMLInputElement>(null);
 
-  const backgroundImage = assetInfo?.hero_banner_available
+  const heroBannerAvailable = assetInfo?.assets.some(
+    (asset) => asset.slot === 'hero-banner' && asset.available
+  );
+
+  const backgroundImage = heroBannerAvailable
     ? getApiAssetUrl('/assets/hero-banner')
     : '';
 